@@ -7,7 +7,6 @@ package abcutil
 
 import (
 	"errors"
-	"fmt"
 
 	"golang.org/x/crypto/ripemd160"
 
@@ -127,106 +126,138 @@ type Address interface {
 
 // DecodeAddress decodes the string encoding of an address and returns
 // the Address if addr is a valid encoding for a known address type on
-// the network provided.
+// the network provided.  Both the base58 encoding returned by EncodeAddress
+// and the bech32 encoding returned by EncodeAddressBech32 are accepted.
+//
+// Failures other than a missing default network are reported as an
+// AddressDecodeError which identifies the stage of decoding that failed.  The
+// error wraps ErrChecksumMismatch, ErrUnknownAddressType, or another error
+// describing the kind of failure so it may be tested with errors.Is.
 func DecodeAddress(addr string, defaultNet *chaincfg.Params) (Address, error) {
-	// Switch on decoded length to determine the type.
+	// Addresses in the bech32 encoding are identified by a human-readable
+	// part belonging to the requested network or any registered network.
+	if hrp, ok := bech32AddressHRP(addr); ok && defaultNet != nil {
+		_, registered := defaultNetRegistry.LookupHRP(hrp)
+		if registered || hrp == Bech32HRP(defaultNet) {
+			return decodeAddressBech32(addr, defaultNet)
+		}
+	}
+
+	decoded, netID, err := checkDecodeAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	if defaultNet == nil {
+		return nil, ErrMissingDefaultNet
+	}
+	return decodeAddressPayload(decoded, netID, defaultNet)
+}
+
+// checkDecodeAddress decodes the base58 string encoding of an address into its
+// payload and two-byte network identifier.  Failures are reported as an
+// AddressDecodeError.
+func checkDecodeAddress(addr string) ([]byte, [2]byte, error) {
 	decoded, netID, err := base58.CheckDecode(addr)
 	if err != nil {
+		if idx := base58.InvalidCharIndex(addr); idx >= 0 {
+			return nil, netID, &AddressDecodeError{
+				Stage: DecodeStageAlphabet,
+				Index: idx,
+				Err:   ErrInvalidAddressChar,
+			}
+		}
 		if err == base58.ErrChecksum {
-			return nil, ErrChecksumMismatch
+			return nil, netID, addressDecodeError(DecodeStageChecksum,
+				ErrChecksumMismatch)
 		}
-		return nil, fmt.Errorf("decoded address is of unknown format: %v",
-			err.Error())
+		return nil, netID, addressDecodeError(DecodeStageLength,
+			ErrInvalidAddressLength)
 	}
-	if defaultNet == nil {
-		return nil, ErrMissingDefaultNet
+	return decoded, netID, nil
+}
+
+// decodePubKeyAddress returns the pay-to-pubkey address described by the
+// decoded payload of a pubkey address for the passed network.  The first byte
+// of the payload is the signature suite and, for secp256k1 suites, the y-bit
+// of the public key, and the remaining 32 bytes are the public key.
+func decodePubKeyAddress(decoded []byte, net *chaincfg.Params) (Address, error) {
+	if len(decoded) != 33 {
+		return nil, addressDecodeError(DecodeStageLength,
+			ErrInvalidAddressLength)
+	}
+	suite := decoded[0]
+	suite &= ^uint8(1 << 7)
+	ybit := !(decoded[0]&(1<<7) == 0)
+	toAppend := uint8(0x02)
+	if ybit {
+		toAppend = 0x03
+	}
+
+	var addr Address
+	var err error
+	switch int(suite) {
+	case chainec.ECTypeSecp256k1:
+		addr, err = NewAddressSecpPubKey(
+			append([]byte{toAppend}, decoded[1:]...), net)
+	case chainec.ECTypeEdwards:
+		addr, err = NewAddressEdwardsPubKey(decoded[1:], net)
+	case chainec.ECTypeSecSchnorr:
+		addr, err = NewAddressSecSchnorrPubKey(
+			append([]byte{toAppend}, decoded[1:]...), net)
+	default:
+		return nil, addressDecodeError(DecodeStageSuite,
+			ErrUnknownAddressType)
 	}
+	if err != nil {
+		return nil, addressDecodeError(DecodeStagePubKey, err)
+	}
+	return addr, nil
+}
+
+// decodeAddressPayload returns the Address described by the decoded payload
+// and two-byte network identifier of an address for the passed network.
+// Failures are reported as an AddressDecodeError.
+func decodeAddressPayload(decoded []byte, netID [2]byte,
+	defaultNet *chaincfg.Params) (Address, error) {
+
+	// Switch on the network identifier to determine the type.
+	var dsa int
 	switch netID {
 	case defaultNet.PubKeyAddrID:
-		// First byte is the signature suite and ybit.
-		suite := decoded[0]
-		suite &= ^uint8(1 << 7)
-		ybit := !(decoded[0]&(1<<7) == 0)
-
-		switch int(suite) {
-		case chainec.ECTypeSecp256k1:
-			if len(decoded) == 33 {
-				toAppend := uint8(0x02)
-				if ybit {
-					toAppend = 0x03
-				}
-				return NewAddressSecpPubKey(
-					append([]byte{toAppend}, decoded[1:]...),
-					defaultNet)
-			}
-		case chainec.ECTypeEdwards:
-			if len(decoded) == 33 {
-				return NewAddressEdwardsPubKey(decoded, defaultNet)
-			}
-		case chainec.ECTypeSecSchnorr:
-			if len(decoded) == 33 {
-				toAppend := uint8(0x02)
-				if ybit {
-					toAppend = 0x03
-				}
-				return NewAddressSecSchnorrPubKey(
-					append([]byte{toAppend}, decoded[1:]...),
-					defaultNet)
-			}
-		}
-		return nil, ErrUnknownAddressType
+		return decodePubKeyAddress(decoded, defaultNet)
 
 	case defaultNet.PubKeyHashAddrID:
-		return NewAddressPubKeyHash(decoded, defaultNet, chainec.ECTypeSecp256k1)
+		dsa = chainec.ECTypeSecp256k1
 
 	case defaultNet.PKHEdwardsAddrID:
-		return NewAddressPubKeyHash(decoded, defaultNet, chainec.ECTypeEdwards)
+		dsa = chainec.ECTypeEdwards
 
 	case defaultNet.PKHSchnorrAddrID:
-		return NewAddressPubKeyHash(decoded, defaultNet, chainec.ECTypeSecSchnorr)
+		dsa = chainec.ECTypeSecSchnorr
 
 	case defaultNet.ScriptHashAddrID:
+		if len(decoded) != ripemd160.Size {
+			return nil, addressDecodeError(DecodeStageLength,
+				ErrInvalidAddressLength)
+		}
 		return NewAddressScriptHashFromHash(decoded, defaultNet)
 
 	default:
-		return nil, ErrUnknownAddressType
-	}
-}
-
-// detectNetworkForAddress pops the first character from a string encoded
-// address and detects what network type it is for.
-func detectNetworkForAddress(addr string) (*chaincfg.Params, error) {
-	if len(addr) < 1 {
-		return nil, fmt.Errorf("empty string given for network detection")
-	}
-
-	networkChar := addr[0:1]
-	switch networkChar {
-	case chaincfg.MainNetParams.NetworkAddressPrefix:
-		return &chaincfg.MainNetParams, nil
-	case chaincfg.TestNet2Params.NetworkAddressPrefix:
-		return &chaincfg.TestNet2Params, nil
-	case chaincfg.SimNetParams.NetworkAddressPrefix:
-		return &chaincfg.SimNetParams, nil
-	}
-
-	return nil, fmt.Errorf("unknown network type in string encoded address")
-}
-
-// DecodeNetworkAddress decodes the string encoding of an address and returns
-// the Address if addr is a valid encoding for a known address type. The network
-// type is automatically detected.
-//
-// The network the address is associated with is extracted if possible.
-// When the address does not encode the network, such as in the case of a raw
-// public key, the address will be associated with the passed defaultNet.
-func DecodeNetworkAddress(addr string) (Address, error) {
-	params, err := detectNetworkForAddress(addr)
-	if err != nil {
+		// Report the network the identifier belongs to when it is
+		// known so callers can tell the user which network the
+		// address is for.
+		err := addressDecodeError(DecodeStageNetwork, ErrUnknownAddressType)
+		if net, ok := defaultNetRegistry.LookupAddrID(netID); ok {
+			err.Net = net
+		}
 		return nil, err
 	}
 
-	return DecodeAddress(addr, params)
+	if len(decoded) != ripemd160.Size {
+		return nil, addressDecodeError(DecodeStageLength,
+			ErrInvalidAddressLength)
+	}
+	return NewAddressPubKeyHash(decoded, defaultNet, dsa)
 }
 
 // AddressPubKeyHash is an Address for a pay-to-pubkey-hash (P2PKH)
@@ -738,7 +769,7 @@ func (a *AddressSecSchnorrPubKey) Hash160() *[ripemd160.Size]byte {
 // IsForNet returns whether or not the pay-to-pubkey address is associated
 // with the passed network.
 func (a *AddressSecSchnorrPubKey) IsForNet(net *chaincfg.Params) bool {
-	return a.pubKeyHashID == net.PubKeyHashAddrID
+	return a.pubKeyHashID == net.PKHSchnorrAddrID
 }
 
 // String returns the hex-encoded human-readable string for the pay-to-pubkey
@@ -0,0 +1,144 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"strings"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcd/wire"
+	"github.com/abcsuite/abcutil/bech32"
+)
+
+// bech32HRPs houses the human-readable parts of the bech32 address encoding
+// of the standard networks.
+var bech32HRPs = map[wire.CurrencyNet]string{
+	chaincfg.MainNetParams.Net:  "abc",
+	chaincfg.TestNet2Params.Net: "tabc",
+	chaincfg.SimNetParams.Net:   "sabc",
+}
+
+// Bech32HRP returns the human-readable part which prefixes the bech32 encoding
+// of addresses for the passed network.  It is "abc", "tabc", and "sabc" for the
+// main, test, and simulation networks, respectively, and the lowercase name of
+// the network for any other network.
+func Bech32HRP(net *chaincfg.Params) string {
+	if hrp, ok := bech32HRPs[net.Net]; ok {
+		return hrp
+	}
+	return strings.ToLower(net.Name)
+}
+
+// encodeAddressBech32 returns the bech32 encoding of an address with the
+// passed payload and two-byte network identifier for the passed network.  The
+// data part encodes the network identifier followed by the payload so every
+// address type has a distinct encoding.
+func encodeAddressBech32(payload []byte, netID [2]byte, net *chaincfg.Params) string {
+	if net == nil {
+		return ""
+	}
+	data := make([]byte, 0, len(netID)+len(payload))
+	data = append(data, netID[:]...)
+	data = append(data, payload...)
+	encoded, err := bech32.EncodeFromBase256(Bech32HRP(net), data)
+	if err != nil {
+		return ""
+	}
+	return encoded
+}
+
+// bech32AddressHRP returns the lowercase human-readable part of the passed
+// address and whether or not the address has the form of a bech32 encoding,
+// that is, it is entirely lowercase or entirely uppercase and contains a
+// separator.
+func bech32AddressHRP(addr string) (string, bool) {
+	lower := strings.ToLower(addr)
+	if addr != lower && addr != strings.ToUpper(addr) {
+		return "", false
+	}
+	sep := strings.LastIndexByte(lower, '1')
+	if sep < 1 {
+		return "", false
+	}
+	return lower[:sep], true
+}
+
+// decodeAddressBech32 decodes the bech32 encoding of an address for the passed
+// network.  Failures are reported as an AddressDecodeError.
+func decodeAddressBech32(addr string, net *chaincfg.Params) (Address, error) {
+	hrp, data, err := bech32.DecodeToBase256(addr)
+	switch err := err.(type) {
+	case nil:
+	case bech32.InvalidCharError:
+		return nil, &AddressDecodeError{
+			Stage: DecodeStageAlphabet,
+			Index: err.Index,
+			Err:   ErrInvalidAddressChar,
+		}
+	case bech32.ChecksumError:
+		return nil, &AddressDecodeError{
+			Stage: DecodeStageChecksum,
+			Index: err.Index,
+			Err:   ErrChecksumMismatch,
+		}
+	default:
+		if err == bech32.ErrMixedCase {
+			return nil, addressDecodeError(DecodeStageAlphabet, err)
+		}
+		return nil, addressDecodeError(DecodeStageLength,
+			ErrInvalidAddressLength)
+	}
+
+	if hrp != Bech32HRP(net) {
+		decodeErr := addressDecodeError(DecodeStageNetwork,
+			ErrUnknownAddressType)
+		if other, ok := defaultNetRegistry.LookupHRP(hrp); ok {
+			decodeErr.Net = other
+		}
+		return nil, decodeErr
+	}
+	if len(data) < 2 {
+		return nil, addressDecodeError(DecodeStageLength,
+			ErrInvalidAddressLength)
+	}
+	var netID [2]byte
+	copy(netID[:], data)
+	return decodeAddressPayload(data[2:], netID, net)
+}
+
+// EncodeAddressBech32 returns the bech32 encoding of a pay-to-pubkey-hash
+// address.  It is an alternative to EncodeAddress whose checksum can locate
+// typos.
+func (a *AddressPubKeyHash) EncodeAddressBech32() string {
+	return encodeAddressBech32(a.hash[:], a.netID, a.net)
+}
+
+// EncodeAddressBech32 returns the bech32 encoding of a pay-to-script-hash
+// address.  It is an alternative to EncodeAddress whose checksum can locate
+// typos.
+func (a *AddressScriptHash) EncodeAddressBech32() string {
+	return encodeAddressBech32(a.hash[:], a.netID, a.net)
+}
+
+// EncodeAddressBech32 returns the bech32 encoding of the public key as a
+// pay-to-pubkey-hash.  It is an alternative to EncodeAddress whose checksum
+// can locate typos.
+func (a *AddressSecpPubKey) EncodeAddressBech32() string {
+	return encodeAddressBech32(Hash160(a.serialize()), a.pubKeyHashID, a.net)
+}
+
+// EncodeAddressBech32 returns the bech32 encoding of the public key as a
+// pay-to-pubkey-hash.  It is an alternative to EncodeAddress whose checksum
+// can locate typos.
+func (a *AddressEdwardsPubKey) EncodeAddressBech32() string {
+	return encodeAddressBech32(Hash160(a.serialize()), a.pubKeyHashID, a.net)
+}
+
+// EncodeAddressBech32 returns the bech32 encoding of the public key as a
+// pay-to-pubkey-hash.  It is an alternative to EncodeAddress whose checksum
+// can locate typos.
+func (a *AddressSecSchnorrPubKey) EncodeAddressBech32() string {
+	return encodeAddressBech32(Hash160(a.serialize()), a.pubKeyHashID, a.net)
+}
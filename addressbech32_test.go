@@ -0,0 +1,115 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcd/chaincfg/chainec"
+	"github.com/abcsuite/abcutil"
+)
+
+// bech32Address is implemented by the address types which support the bech32
+// encoding.
+type bech32Address interface {
+	abcutil.Address
+	EncodeAddressBech32() string
+}
+
+// TestAddressBech32 ensures addresses round trip through the bech32 encoding in
+// both cases and decode to the same address as their base58 encoding.
+func TestAddressBech32(t *testing.T) {
+	mainNet := &chaincfg.MainNetParams
+	hash := hexToBytes("e34cce70c86373273efcc54ce7d2a491bb4a0e84")
+	pkh, _ := abcutil.NewAddressPubKeyHash(hash, mainNet,
+		chainec.ECTypeSecp256k1)
+	edwardsPKH, _ := abcutil.NewAddressPubKeyHash(hash,
+		&chaincfg.TestNet2Params, chainec.ECTypeEdwards)
+	sh, _ := abcutil.NewAddressScriptHashFromHash(hash,
+		&chaincfg.SimNetParams)
+	secpKey, _ := abcutil.NewAddressSecpPubKey(hexToBytes(
+		"0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"),
+		mainNet)
+	edwardsKey, _ := abcutil.NewAddressEdwardsPubKey(hexToBytes(
+		"cecc1507dc1ddd7295951c290888f095adb9044d1b73d696e6df065d683bd4fc"),
+		mainNet)
+
+	tests := []struct {
+		name   string
+		addr   bech32Address
+		prefix string
+	}{
+		{"secp256k1 pubkey hash", pkh, "abc1"},
+		{"ed25519 pubkey hash", edwardsPKH, "tabc1"},
+		{"script hash", sh, "sabc1"},
+		{"secp256k1 pubkey", secpKey, "abc1"},
+		{"ed25519 pubkey", edwardsKey, "abc1"},
+	}
+	for i, test := range tests {
+		encoded := test.addr.EncodeAddressBech32()
+		if !strings.HasPrefix(encoded, test.prefix) {
+			t.Errorf("EncodeAddressBech32 #%d (%s): mismatched prefix -- "+
+				"got %s, want prefix %s", i, test.name, encoded,
+				test.prefix)
+			continue
+		}
+
+		for _, s := range []string{encoded, strings.ToUpper(encoded)} {
+			addr, err := abcutil.DecodeAddress(s, test.addr.Net())
+			if err != nil {
+				t.Errorf("DecodeAddress #%d (%s): unexpected error: %v",
+					i, s, err)
+				continue
+			}
+			if addr.EncodeAddress() != test.addr.EncodeAddress() {
+				t.Errorf("DecodeAddress #%d (%s): mismatched address -- "+
+					"got %s, want %s", i, s, addr.EncodeAddress(),
+					test.addr.EncodeAddress())
+			}
+
+			addr, err = abcutil.DecodeNetworkAddress(s)
+			if err != nil {
+				t.Errorf("DecodeNetworkAddress #%d (%s): unexpected "+
+					"error: %v", i, s, err)
+				continue
+			}
+			if addr.Net() != test.addr.Net() {
+				t.Errorf("DecodeNetworkAddress #%d (%s): mismatched "+
+					"network -- got %s, want %s", i, s, addr.Net().Name,
+					test.addr.Net().Name)
+			}
+		}
+	}
+
+	// Ensure a typo is located and an address for another network reports
+	// the network it is for.
+	encoded := pkh.EncodeAddressBech32()
+	typo := []byte(encoded)
+	typo[10] = 'q'
+	if encoded[10] == 'q' {
+		typo[10] = 'p'
+	}
+	_, err := abcutil.DecodeAddress(string(typo), mainNet)
+	var decodeErr *abcutil.AddressDecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.Stage !=
+		abcutil.DecodeStageChecksum || decodeErr.Index != 10 ||
+		!errors.Is(err, abcutil.ErrChecksumMismatch) {
+
+		t.Errorf("DecodeAddress: mismatched error -- got %v, want "+
+			"checksum mismatch at index 10", err)
+	}
+
+	_, err = abcutil.DecodeAddress(edwardsPKH.EncodeAddressBech32(), mainNet)
+	if !errors.As(err, &decodeErr) || decodeErr.Stage !=
+		abcutil.DecodeStageNetwork ||
+		decodeErr.Net != &chaincfg.TestNet2Params {
+
+		t.Errorf("DecodeAddress: mismatched error -- got %v, want "+
+			"network mismatch for %s", err, chaincfg.TestNet2Params.Name)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/abcsuite/abcd/chaincfg"
+)
+
+var (
+	// ErrInvalidAddressChar describes an error where an address contains
+	// a character which is not part of the modified base58 alphabet.
+	ErrInvalidAddressChar = errors.New("invalid base58 character in address")
+
+	// ErrInvalidAddressLength describes an error where an address, or the
+	// payload it encodes, is not of a valid length for its type.
+	ErrInvalidAddressLength = errors.New("invalid address length")
+)
+
+// AddressDecodeStage identifies the stage of decoding at which an address was
+// found to be invalid.
+type AddressDecodeStage int
+
+const (
+	// DecodeStageAlphabet indicates the address contains a character
+	// which is not part of the modified base58 alphabet.
+	DecodeStageAlphabet AddressDecodeStage = iota
+
+	// DecodeStageLength indicates the address or its payload is too short
+	// or too long.
+	DecodeStageLength
+
+	// DecodeStageChecksum indicates the checksum of the address does not
+	// match its contents.
+	DecodeStageChecksum
+
+	// DecodeStageNetwork indicates the address identifier does not belong
+	// to the requested network.
+	DecodeStageNetwork
+
+	// DecodeStageSuite indicates a pay-to-pubkey address uses an unknown
+	// signature suite.
+	DecodeStageSuite
+
+	// DecodeStagePubKey indicates a pay-to-pubkey address encodes a public
+	// key which is not a valid point for its signature suite.
+	DecodeStagePubKey
+)
+
+// decodeStageStrings is a map of address decode stages back to their constant
+// names for pretty printing.
+var decodeStageStrings = map[AddressDecodeStage]string{
+	DecodeStageAlphabet: "DecodeStageAlphabet",
+	DecodeStageLength:   "DecodeStageLength",
+	DecodeStageChecksum: "DecodeStageChecksum",
+	DecodeStageNetwork:  "DecodeStageNetwork",
+	DecodeStageSuite:    "DecodeStageSuite",
+	DecodeStagePubKey:   "DecodeStagePubKey",
+}
+
+// String returns the AddressDecodeStage as a human-readable name.
+func (s AddressDecodeStage) String() string {
+	if str, ok := decodeStageStrings[s]; ok {
+		return str
+	}
+	return fmt.Sprintf("Unknown AddressDecodeStage (%d)", int(s))
+}
+
+// AddressDecodeError describes why an address could not be decoded.  Err is
+// one of the sentinel errors of this package, such as ErrChecksumMismatch or
+// ErrUnknownAddressType, or the error returned while parsing a public key, so
+// callers which only need the kind of failure may continue to test for those
+// errors with errors.Is.
+type AddressDecodeError struct {
+	// Stage is the stage of decoding which failed.
+	Stage AddressDecodeStage
+
+	// Index is the index of the offending character for
+	// DecodeStageAlphabet errors, or of the character which is most
+	// likely mistyped for DecodeStageChecksum errors of bech32 encoded
+	// addresses.  It is -1 when the position is not known.
+	Index int
+
+	// Net is the network the address identifier belongs to for
+	// DecodeStageNetwork errors when it is a known network other than the
+	// requested one.  It is nil otherwise.
+	Net *chaincfg.Params
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e *AddressDecodeError) Error() string {
+	switch {
+	case e.Index >= 0:
+		return fmt.Sprintf("%v at index %d", e.Err, e.Index)
+	case e.Net != nil:
+		return fmt.Sprintf("%v: address is for network %s", e.Err,
+			e.Net.Name)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error so the kind of failure may be tested
+// with errors.Is.
+func (e *AddressDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// addressDecodeError creates an AddressDecodeError for a stage other than
+// DecodeStageAlphabet given a set of arguments.
+func addressDecodeError(stage AddressDecodeStage, err error) *AddressDecodeError {
+	return &AddressDecodeError{Stage: stage, Index: -1, Err: err}
+}
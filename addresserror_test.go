@@ -0,0 +1,134 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcd/chaincfg/chainec"
+	"github.com/abcsuite/abcutil"
+	"github.com/abcsuite/abcutil/base58"
+)
+
+// TestAddressDecodeError ensures failures to decode an address report the
+// stage which failed along with the position or network hints and remain
+// comparable to the sentinel errors.
+func TestAddressDecodeError(t *testing.T) {
+	mainNet := &chaincfg.MainNetParams
+	hash := hexToBytes("e34cce70c86373273efcc54ce7d2a491bb4a0e84")
+	pkh, _ := abcutil.NewAddressPubKeyHash(hash, mainNet,
+		chainec.ECTypeSecp256k1)
+	valid := pkh.EncodeAddress()
+	testPKH, _ := abcutil.NewAddressPubKeyHash(hash,
+		&chaincfg.TestNet2Params, chainec.ECTypeSecp256k1)
+
+	// Flip the final character of the valid address to a different valid
+	// character so only the checksum fails.
+	last := byte('2')
+	if valid[len(valid)-1] == last {
+		last = '3'
+	}
+	badChecksum := valid[:len(valid)-1] + string(last)
+
+	// A pubkey address with an unknown signature suite and one with an x
+	// coordinate which is not on the curve.
+	badSuite := append([]byte{0x05}, bytes.Repeat([]byte{0x01}, 32)...)
+	badPoint := append([]byte{0x00}, bytes.Repeat([]byte{0xff}, 32)...)
+
+	tests := []struct {
+		name     string
+		addr     string
+		stage    abcutil.AddressDecodeStage
+		index    int
+		net      *chaincfg.Params
+		sentinel error
+	}{
+		{
+			name:     "invalid character",
+			addr:     valid[:5] + "0" + valid[6:],
+			stage:    abcutil.DecodeStageAlphabet,
+			index:    5,
+			sentinel: abcutil.ErrInvalidAddressChar,
+		},
+		{
+			name:     "too short",
+			addr:     "1111",
+			stage:    abcutil.DecodeStageLength,
+			index:    -1,
+			sentinel: abcutil.ErrInvalidAddressLength,
+		},
+		{
+			name:     "short hash",
+			addr:     base58.CheckEncode(hash[:19], mainNet.PubKeyHashAddrID),
+			stage:    abcutil.DecodeStageLength,
+			index:    -1,
+			sentinel: abcutil.ErrInvalidAddressLength,
+		},
+		{
+			name:     "checksum",
+			addr:     badChecksum,
+			stage:    abcutil.DecodeStageChecksum,
+			index:    -1,
+			sentinel: abcutil.ErrChecksumMismatch,
+		},
+		{
+			name:     "other network",
+			addr:     testPKH.EncodeAddress(),
+			stage:    abcutil.DecodeStageNetwork,
+			index:    -1,
+			net:      &chaincfg.TestNet2Params,
+			sentinel: abcutil.ErrUnknownAddressType,
+		},
+		{
+			name:     "unknown network",
+			addr:     base58.CheckEncode(hash, [2]byte{0xff, 0xff}),
+			stage:    abcutil.DecodeStageNetwork,
+			index:    -1,
+			sentinel: abcutil.ErrUnknownAddressType,
+		},
+		{
+			name:     "unknown suite",
+			addr:     base58.CheckEncode(badSuite, mainNet.PubKeyAddrID),
+			stage:    abcutil.DecodeStageSuite,
+			index:    -1,
+			sentinel: abcutil.ErrUnknownAddressType,
+		},
+		{
+			name:  "invalid point",
+			addr:  base58.CheckEncode(badPoint, mainNet.PubKeyAddrID),
+			stage: abcutil.DecodeStagePubKey,
+			index: -1,
+		},
+	}
+
+	for i, test := range tests {
+		_, err := abcutil.DecodeAddress(test.addr, mainNet)
+		var decodeErr *abcutil.AddressDecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Errorf("DecodeAddress #%d (%s): unexpected error type %T "+
+				"(%v)", i, test.name, err, err)
+			continue
+		}
+		if decodeErr.Stage != test.stage {
+			t.Errorf("DecodeAddress #%d (%s): mismatched stage -- got "+
+				"%v, want %v", i, test.name, decodeErr.Stage, test.stage)
+		}
+		if decodeErr.Index != test.index {
+			t.Errorf("DecodeAddress #%d (%s): mismatched index -- got "+
+				"%d, want %d", i, test.name, decodeErr.Index, test.index)
+		}
+		if decodeErr.Net != test.net {
+			t.Errorf("DecodeAddress #%d (%s): mismatched network -- got "+
+				"%v, want %v", i, test.name, decodeErr.Net, test.net)
+		}
+		if test.sentinel != nil && !errors.Is(err, test.sentinel) {
+			t.Errorf("DecodeAddress #%d (%s): error %v is not %v", i,
+				test.name, err, test.sentinel)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/abcsuite/abcd/chaincfg"
+)
+
+// ErrWrongAddressNetwork describes an error where an address is not for the
+// network an AddressValue is bound to.
+var ErrWrongAddressNetwork = errors.New("address is not for the expected " +
+	"network")
+
+// AddressValue wraps an Address for a specific network so it may be used as a
+// field of structs which are encoded as text, JSON, or stored in a SQL
+// database.  It implements the encoding.TextMarshaler, json.Marshaler,
+// sql.Scanner, and driver.Valuer interfaces along with their unmarshalling
+// counterparts.
+//
+// The address is encoded with its String method, so pay-to-pubkey addresses
+// retain their public key, and decoded with DecodeAddress for the bound
+// network.  A nil Address is encoded as an empty string, JSON null, or SQL
+// NULL, respectively.
+type AddressValue struct {
+	// Address is the wrapped address.  It may be nil.
+	Address Address
+
+	// Net is the network the address must be for.
+	Net *chaincfg.Params
+}
+
+// NewAddressValue returns a new AddressValue which wraps the passed address
+// and is bound to the passed network.
+func NewAddressValue(addr Address, net *chaincfg.Params) *AddressValue {
+	return &AddressValue{Address: addr, Net: net}
+}
+
+// encode returns the string encoding of the wrapped address after ensuring it
+// is for the bound network.
+func (v *AddressValue) encode() (string, error) {
+	if v.Net == nil {
+		return "", ErrMissingDefaultNet
+	}
+	if !v.Address.IsForNet(v.Net) {
+		return "", ErrWrongAddressNetwork
+	}
+	return v.Address.String(), nil
+}
+
+// decode sets the wrapped address to the address decoded from the passed
+// string for the bound network, or nil when the string is empty.  An address
+// which does not belong to the bound network results in
+// ErrWrongAddressNetwork, the same as when encoding, rather than the
+// AddressDecodeError returned by DecodeAddress.
+func (v *AddressValue) decode(s string) error {
+	if s == "" {
+		v.Address = nil
+		return nil
+	}
+	if v.Net == nil {
+		return ErrMissingDefaultNet
+	}
+	addr, err := DecodeAddress(s, v.Net)
+	if decodeErr, ok := err.(*AddressDecodeError); ok &&
+		decodeErr.Stage == DecodeStageNetwork {
+
+		return ErrWrongAddressNetwork
+	}
+	if err != nil {
+		return err
+	}
+	v.Address = addr
+	return nil
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+// ErrWrongAddressNetwork is returned when the address is not for the bound
+// network.
+func (v AddressValue) MarshalText() ([]byte, error) {
+	if v.Address == nil {
+		return []byte{}, nil
+	}
+	s, err := v.encode()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.  The Net
+// field must be set to the expected network beforehand, and
+// ErrWrongAddressNetwork is returned when the address is for another network.
+func (v *AddressValue) UnmarshalText(text []byte) error {
+	return v.decode(string(text))
+}
+
+// MarshalJSON satisfies the json.Marshaler interface.
+// ErrWrongAddressNetwork is returned when the address is not for the bound
+// network.
+func (v AddressValue) MarshalJSON() ([]byte, error) {
+	if v.Address == nil {
+		return []byte("null"), nil
+	}
+	s, err := v.encode()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface.  The Net field must
+// be set to the expected network beforehand, and ErrWrongAddressNetwork is
+// returned when the address is for another network.
+func (v *AddressValue) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		v.Address = nil
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.decode(s)
+}
+
+// Scan satisfies the sql.Scanner interface.  Strings and byte slices are
+// decoded as addresses and NULL is scanned as a nil Address.  The Net field
+// must be set to the expected network beforehand, and ErrWrongAddressNetwork is
+// returned when the address is for another network.
+func (v *AddressValue) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		v.Address = nil
+		return nil
+	case string:
+		return v.decode(src)
+	case []byte:
+		return v.decode(string(src))
+	}
+	return fmt.Errorf("unable to scan type %T into an address", src)
+}
+
+// Value satisfies the driver.Valuer interface.  The address is stored as a
+// string, or NULL when it is nil.  ErrWrongAddressNetwork is returned when the
+// address is not for the bound network.
+func (v AddressValue) Value() (driver.Value, error) {
+	if v.Address == nil {
+		return nil, nil
+	}
+	return v.encode()
+}
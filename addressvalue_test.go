@@ -0,0 +1,169 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcd/chaincfg/chainec"
+	"github.com/abcsuite/abcutil"
+)
+
+// TestAddressValue ensures every address type round trips through the text,
+// JSON, and SQL encodings of AddressValue.
+func TestAddressValue(t *testing.T) {
+	net := &chaincfg.MainNetParams
+	hash := hexToBytes("e34cce70c86373273efcc54ce7d2a491bb4a0e84")
+	secpKey := hexToBytes("0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	edwardsKey := hexToBytes("cecc1507dc1ddd7295951c290888f095adb9044d1b73d696e6df065d683bd4fc")
+	mustAddr := func(addr abcutil.Address, err error) abcutil.Address {
+		if err != nil {
+			t.Fatalf("unexpected error creating address: %v", err)
+		}
+		return addr
+	}
+
+	addrs := []abcutil.Address{
+		mustAddr(abcutil.NewAddressPubKeyHash(hash, net, chainec.ECTypeSecp256k1)),
+		mustAddr(abcutil.NewAddressPubKeyHash(hash, net, chainec.ECTypeEdwards)),
+		mustAddr(abcutil.NewAddressPubKeyHash(hash, net, chainec.ECTypeSecSchnorr)),
+		mustAddr(abcutil.NewAddressScriptHashFromHash(hash, net)),
+		mustAddr(abcutil.NewAddressSecpPubKey(secpKey, net)),
+		mustAddr(abcutil.NewAddressEdwardsPubKey(edwardsKey, net)),
+		mustAddr(abcutil.NewAddressSecSchnorrPubKey(secpKey, net)),
+	}
+
+	type record struct {
+		Addr abcutil.AddressValue `json:"addr"`
+	}
+	for i, addr := range addrs {
+		v := abcutil.NewAddressValue(addr, net)
+
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Errorf("MarshalText #%d: unexpected error: %v", i, err)
+			continue
+		}
+		decoded := abcutil.AddressValue{Net: net}
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Errorf("UnmarshalText #%d: unexpected error: %v", i, err)
+			continue
+		}
+		if decoded.Address.String() != addr.String() {
+			t.Errorf("UnmarshalText #%d: mismatched address -- got %s, "+
+				"want %s", i, decoded.Address, addr)
+		}
+
+		b, err := json.Marshal(record{Addr: *v})
+		if err != nil {
+			t.Errorf("json.Marshal #%d: unexpected error: %v", i, err)
+			continue
+		}
+		if want := `{"addr":"` + addr.String() + `"}`; string(b) != want {
+			t.Errorf("json.Marshal #%d: mismatched JSON -- got %s, want "+
+				"%s", i, b, want)
+		}
+		r := record{Addr: abcutil.AddressValue{Net: net}}
+		if err := json.Unmarshal(b, &r); err != nil {
+			t.Errorf("json.Unmarshal #%d: unexpected error: %v", i, err)
+			continue
+		}
+		if r.Addr.Address.String() != addr.String() {
+			t.Errorf("json.Unmarshal #%d: mismatched address -- got %s, "+
+				"want %s", i, r.Addr.Address, addr)
+		}
+
+		value, err := v.Value()
+		if err != nil {
+			t.Errorf("Value #%d: unexpected error: %v", i, err)
+			continue
+		}
+		scanned := abcutil.AddressValue{Net: net}
+		if err := scanned.Scan([]byte(value.(string))); err != nil {
+			t.Errorf("Scan #%d: unexpected error: %v", i, err)
+			continue
+		}
+		if scanned.Address.String() != addr.String() {
+			t.Errorf("Scan #%d: mismatched address -- got %s, want %s", i,
+				scanned.Address, addr)
+		}
+	}
+}
+
+// TestAddressValueErrors ensures nil addresses are encoded as empty values and
+// addresses for other networks are rejected.
+func TestAddressValueErrors(t *testing.T) {
+	net := &chaincfg.MainNetParams
+	hash := hexToBytes("e34cce70c86373273efcc54ce7d2a491bb4a0e84")
+	testAddr, _ := abcutil.NewAddressPubKeyHash(hash,
+		&chaincfg.TestNet2Params, chainec.ECTypeSecp256k1)
+
+	// Nil addresses.
+	empty := abcutil.AddressValue{Net: net}
+	if b, err := json.Marshal(empty); err != nil || string(b) != "null" {
+		t.Errorf("json.Marshal: got %s (%v), want null", b, err)
+	}
+	if value, err := empty.Value(); err != nil || value != nil {
+		t.Errorf("Value: got %v (%v), want nil", value, err)
+	}
+	v := abcutil.AddressValue{Address: testAddr, Net: net}
+	if err := v.Scan(nil); err != nil || v.Address != nil {
+		t.Errorf("Scan: got %v (%v), want nil address", v.Address, err)
+	}
+	if err := v.UnmarshalJSON([]byte("null")); err != nil || v.Address != nil {
+		t.Errorf("UnmarshalJSON: got %v (%v), want nil address",
+			v.Address, err)
+	}
+
+	// Network mismatches.
+	v = abcutil.AddressValue{Address: testAddr, Net: net}
+	if _, err := v.MarshalText(); err != abcutil.ErrWrongAddressNetwork {
+		t.Errorf("MarshalText: mismatched error -- got %v, want %v", err,
+			abcutil.ErrWrongAddressNetwork)
+	}
+	if _, err := v.Value(); err != abcutil.ErrWrongAddressNetwork {
+		t.Errorf("Value: mismatched error -- got %v, want %v", err,
+			abcutil.ErrWrongAddressNetwork)
+	}
+	err := v.UnmarshalText([]byte(testAddr.String()))
+	if err != abcutil.ErrWrongAddressNetwork {
+		t.Errorf("UnmarshalText: mismatched error -- got %v, want %v",
+			err, abcutil.ErrWrongAddressNetwork)
+	}
+	err = v.UnmarshalJSON([]byte(`"` + testAddr.String() + `"`))
+	if err != abcutil.ErrWrongAddressNetwork {
+		t.Errorf("UnmarshalJSON: mismatched error -- got %v, want %v",
+			err, abcutil.ErrWrongAddressNetwork)
+	}
+	if err := v.Scan(testAddr.String()); err != abcutil.ErrWrongAddressNetwork {
+		t.Errorf("Scan: mismatched error -- got %v, want %v", err,
+			abcutil.ErrWrongAddressNetwork)
+	}
+	err = v.UnmarshalText([]byte(testAddr.EncodeAddressBech32()))
+	if err != abcutil.ErrWrongAddressNetwork {
+		t.Errorf("UnmarshalText (bech32): mismatched error -- got %v, "+
+			"want %v", err, abcutil.ErrWrongAddressNetwork)
+	}
+
+	// Other decoding failures are reported as they are by DecodeAddress.
+	err = v.UnmarshalText([]byte("invalid"))
+	if _, ok := err.(*abcutil.AddressDecodeError); !ok {
+		t.Errorf("UnmarshalText: mismatched error -- got %T, want %T",
+			err, &abcutil.AddressDecodeError{})
+	}
+
+	// Unsupported types and missing networks.
+	if err := v.Scan(42); err == nil {
+		t.Errorf("Scan: expected error for unsupported type")
+	}
+	unbound := abcutil.AddressValue{}
+	err = unbound.UnmarshalText([]byte(testAddr.String()))
+	if err != abcutil.ErrMissingDefaultNet {
+		t.Errorf("UnmarshalText: mismatched error -- got %v, want %v",
+			err, abcutil.ErrMissingDefaultNet)
+	}
+}
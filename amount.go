@@ -9,6 +9,32 @@ import (
 	"errors"
 	"math"
 	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidAmount describes an error where a string does not
+	// describe a monetary amount which can be represented exactly in
+	// atoms.
+	ErrInvalidAmount = errors.New("invalid amount")
+
+	// ErrUnknownAmountUnit describes an error where the unit of an amount
+	// string is not recognized.
+	ErrUnknownAmountUnit = errors.New("unknown amount unit")
+
+	// ErrNegativeAmount describes an error where checked arithmetic on
+	// amounts involves or results in a negative amount.
+	ErrNegativeAmount = errors.New("amount is negative")
+
+	// ErrAmountExceedsMax describes an error where checked arithmetic on
+	// amounts involves or results in an amount greater than MaxAmount.
+	// Since MaxAmount is far below the maximum int64, this is also the
+	// error for results which would otherwise overflow.
+	ErrAmountExceedsMax = errors.New("amount exceeds the maximum amount")
+
+	// ErrAmountDivideByZero describes an error where an amount is divided
+	// by zero.
+	ErrAmountDivideByZero = errors.New("amount divided by zero")
 )
 
 // AmountUnit describes a method of converting an Amount to something
@@ -28,26 +54,26 @@ const (
 	AmountAtom      AmountUnit = -8
 )
 
-// String returns the unit as a string.  For recognized units, the SI
-// prefix is used, or "Atom" for the base unit.  For all unrecognized
-// units, "1eN ABC" is returned, where N is the AmountUnit.
+const (
+	// minAmountUnit is the smallest unit amounts may be parsed in, since a
+	// single unit must be a whole number of atoms.
+	minAmountUnit = AmountAtom
+
+	// maxAmountUnit is the largest unit amounts may be parsed in, since
+	// the number of atoms in a single unit, 1e18, must fit in an Amount.
+	maxAmountUnit AmountUnit = 10
+
+	// maxAmountDigits is the maximum number of decimal digits of the
+	// magnitude of an Amount.
+	maxAmountDigits = 19
+)
+
+// String returns the unit as a string using the amount unit registry returned
+// by AmountUnits.  For the standard units of the default registry, the SI
+// prefix is used, or "Atom" for the base unit.  For all unrecognized units,
+// "1eN ABC" is returned, where N is the AmountUnit.
 func (u AmountUnit) String() string {
-	switch u {
-	case AmountMegaCoin:
-		return "MABC"
-	case AmountKiloCoin:
-		return "kABC"
-	case AmountCoin:
-		return "ABC"
-	case AmountMilliCoin:
-		return "mABC"
-	case AmountMicroCoin:
-		return "μABC"
-	case AmountAtom:
-		return "Atom"
-	default:
-		return "1e" + strconv.FormatInt(int64(u), 10) + " ABC"
-	}
+	return AmountUnits().UnitString(u)
 }
 
 // Amount represents the base coin monetary unit (colloquially referred
@@ -89,6 +115,174 @@ func NewAmount(f float64) (Amount, error) {
 	return round(f * AtomsPerCoin), nil
 }
 
+// parseDecimalAmount parses the passed unsigned decimal string, which may have
+// a fractional part, as an amount in the passed unit and returns the exact
+// amount in atoms.  The string is parsed without any floating point
+// conversion, and ErrInvalidAmount is returned when it is malformed, has more
+// significant fractional digits than can be represented in atoms, or
+// overflows an Amount.
+func parseDecimalAmount(s string, u AmountUnit) (Amount, error) {
+	if u < minAmountUnit || u > maxAmountUnit {
+		return 0, ErrInvalidAmount
+	}
+	decimals := int(u) + 8
+
+	intPart, fracPart := s, ""
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		intPart, fracPart = s[:dot], s[dot+1:]
+	}
+	if len(intPart) == 0 && len(fracPart) == 0 {
+		return 0, ErrInvalidAmount
+	}
+	for _, digits := range []string{intPart, fracPart} {
+		for i := 0; i < len(digits); i++ {
+			if digits[i] < '0' || digits[i] > '9' {
+				return 0, ErrInvalidAmount
+			}
+		}
+	}
+
+	// Fractional digits beyond the precision of an atom are only allowed
+	// when they are zero.
+	if len(fracPart) > decimals {
+		if strings.TrimRight(fracPart[decimals:], "0") != "" {
+			return 0, ErrInvalidAmount
+		}
+		fracPart = fracPart[:decimals]
+	}
+
+	// Reject numbers with more integer digits than an Amount can hold
+	// before padding the fractional part with zeros.
+	intPart = strings.TrimLeft(intPart, "0")
+	if len(intPart)+decimals > maxAmountDigits {
+		return 0, ErrInvalidAmount
+	}
+	digits := intPart + fracPart + strings.Repeat("0", decimals-len(fracPart))
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return 0, nil
+	}
+	atoms, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	return Amount(atoms), nil
+}
+
+// AmountFormatFlags modify how an Amount is formatted by FormatExact and
+// FormatLocale.
+type AmountFormatFlags uint8
+
+const (
+	// AmountThousands groups the digits of the integer part of the amount
+	// into threes separated by commas, or the grouping separator of the
+	// locale passed to FormatLocale.
+	AmountThousands AmountFormatFlags = 1 << iota
+
+	// AmountTrimZeros removes trailing zeros from the fractional part of
+	// the amount, along with the decimal point when no fractional digits
+	// remain.  Otherwise, the fractional part has as many digits as are
+	// needed to represent a single atom in the unit.
+	AmountTrimZeros
+
+	// AmountOmitUnit omits the unit which is otherwise appended to the
+	// formatted amount.
+	AmountOmitUnit
+)
+
+// groupThousands returns the passed string of digits with a separator inserted
+// between each group of three digits counting from the right.
+func groupThousands(digits, sep string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	first := len(digits) % 3
+	if first == 0 {
+		first = 3
+	}
+	grouped := make([]byte, 0, len(digits)+(len(digits)-1)/3*len(sep))
+	grouped = append(grouped, digits[:first]...)
+	for i := first; i < len(digits); i += 3 {
+		grouped = append(grouped, sep...)
+		grouped = append(grouped, digits[i:i+3]...)
+	}
+	return string(grouped)
+}
+
+// AmountLocale describes the separators used to format an Amount for display
+// with FormatLocale.
+type AmountLocale struct {
+	// Decimal separates the integer and fractional parts of the amount.
+	// A period is used when it is empty.
+	Decimal string
+
+	// Group separates each group of three integer digits when the
+	// AmountThousands flag is set.  The digits are not grouped when it
+	// is empty.
+	Group string
+}
+
+// defaultAmountLocale is the locale used by FormatExact.
+var defaultAmountLocale = AmountLocale{Decimal: ".", Group: ","}
+
+// formatDecimalAmount returns the exact decimal representation of the passed
+// amount in the passed unit, without the unit, using only integer arithmetic
+// and the separators of the passed locale.
+func formatDecimalAmount(a Amount, u AmountUnit, flags AmountFormatFlags,
+	loc AmountLocale) string {
+
+	// Work with the magnitude as a uint64 so the minimum amount does not
+	// overflow when negated.
+	sign, magnitude := "", uint64(a)
+	if a < 0 {
+		sign, magnitude = "-", uint64(-a)
+	}
+	digits := strconv.FormatUint(magnitude, 10)
+
+	// Amounts in units outside of those amounts may be parsed in are
+	// formatted as the number of atoms scaled by a power of ten, so the
+	// length of the string does not depend on the unit.
+	if u < minAmountUnit || u > maxAmountUnit {
+		if magnitude == 0 {
+			return digits
+		}
+		return sign + digits + "e" + atomExponent(u)
+	}
+
+	// Split the digits into the integer and fractional parts.
+	decimals := int(u) + 8
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	intPart := digits[:len(digits)-decimals]
+	fracPart := digits[len(digits)-decimals:]
+
+	if flags&AmountThousands != 0 && loc.Group != "" {
+		intPart = groupThousands(intPart, loc.Group)
+	}
+	if flags&AmountTrimZeros != 0 {
+		fracPart = strings.TrimRight(fracPart, "0")
+	}
+	if fracPart == "" {
+		return sign + intPart
+	}
+	decimal := loc.Decimal
+	if decimal == "" {
+		decimal = "."
+	}
+	return sign + intPart + decimal + fracPart
+}
+
+// atomExponent returns the power of ten, -(u+8), which scales a number of atoms
+// to an amount in the passed unit.  It is calculated without overflowing for
+// any unit.
+func atomExponent(u AmountUnit) string {
+	if u < AmountAtom {
+		return strconv.FormatInt(-(int64(u) + 8), 10)
+	}
+	return "-" + strconv.FormatUint(uint64(u)+8, 10)
+}
+
 // ToUnit converts a monetary amount counted in coin base units to a
 // floating point value representing an amount of coins.
 func (a Amount) ToUnit(u AmountUnit) float64 {
@@ -103,10 +297,83 @@ func (a Amount) ToCoin() float64 {
 // Format formats a monetary amount counted in coin base units as a
 // string for a given unit.  The conversion will succeed for any unit,
 // however, known units will be formated with an appended label describing
-// the units with SI notation, or "atom" for the base unit.
+// the units with SI notation, or "atom" for the base unit.  The amount is
+// formatted exactly, without trailing fractional zeros, and is the equivalent
+// of calling FormatExact with AmountTrimZeros.  Amounts in units smaller than
+// an atom or larger than 1e10 coins are formatted as a number of atoms with an
+// exponent, such as "12345e-20 1e12 ABC".
 func (a Amount) Format(u AmountUnit) string {
-	units := " " + u.String()
-	return strconv.FormatFloat(a.ToUnit(u), 'f', -int(u+8), 64) + units
+	return a.FormatExact(u, AmountTrimZeros)
+}
+
+// FormatExact formats a monetary amount counted in coin base units as a
+// string for a given unit using integer arithmetic, so every amount is
+// represented exactly regardless of its magnitude.  The passed flags control
+// grouping of the integer digits, trimming of trailing fractional zeros, and
+// whether the unit is appended.
+func (a Amount) FormatExact(u AmountUnit, flags AmountFormatFlags) string {
+	return a.FormatLocale(u, flags, defaultAmountLocale)
+}
+
+// FormatLocale formats a monetary amount counted in coin base units as a
+// string for a given unit in the same manner as FormatExact, except the
+// decimal and grouping separators are taken from the passed locale.  For
+// example, an amount of 123450000000 atoms formatted in AmountCoin with the
+// AmountThousands and AmountTrimZeros flags and a locale with a comma for
+// the decimal separator and a period for the grouping separator is
+// "1.234,5 ABC".
+func (a Amount) FormatLocale(u AmountUnit, flags AmountFormatFlags,
+	loc AmountLocale) string {
+
+	formatted := formatDecimalAmount(a, u, flags, loc)
+	if flags&AmountOmitUnit != 0 {
+		return formatted
+	}
+	return formatted + " " + u.String()
+}
+
+// ParseAmount parses the passed decimal string as an exact amount of atoms.
+// The string may be signed and may be followed by the name of a unit, as
+// accepted by ParseAmountUnit, with or without separating whitespace, such as
+// "1.5 mABC" or "-20ABC".  The passed unit is used when no unit is given.
+//
+// The string is parsed without any floating point conversion.
+// ErrInvalidAmount is returned when it is malformed, has more significant
+// fractional digits than can be represented in atoms, or overflows an Amount,
+// and ErrUnknownAmountUnit is returned when the unit is not recognized.
+func ParseAmount(s string, u AmountUnit) (Amount, error) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	// The number consists of digits and at most one decimal point and is
+	// followed by an optional unit.
+	end := 0
+	for end < len(s) && (s[end] >= '0' && s[end] <= '9' || s[end] == '.') {
+		end++
+	}
+	if end == 0 {
+		return 0, ErrInvalidAmount
+	}
+	if suffix := strings.TrimSpace(s[end:]); suffix != "" {
+		unit, err := ParseAmountUnit(suffix)
+		if err != nil {
+			return 0, err
+		}
+		u = unit
+	}
+
+	amount, err := parseDecimalAmount(s[:end], u)
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
 }
 
 // String is the equivalent of calling Format with AmountCoin.
@@ -122,6 +389,104 @@ func (a Amount) MulF64(f float64) Amount {
 	return round(float64(a) * f)
 }
 
+// IsValid returns whether or not the amount is between zero and MaxAmount
+// inclusive, which is the range of amounts the checked arithmetic functions
+// operate on.
+func (a Amount) IsValid() bool {
+	return a >= 0 && a <= MaxAmount
+}
+
+// check returns ErrNegativeAmount or ErrAmountExceedsMax when the amount is not
+// valid as determined by IsValid.
+func (a Amount) check() error {
+	switch {
+	case a < 0:
+		return ErrNegativeAmount
+	case a > MaxAmount:
+		return ErrAmountExceedsMax
+	}
+	return nil
+}
+
+// Add returns the sum of the amount and the passed amount.  Both amounts must
+// be valid as determined by IsValid, and ErrAmountExceedsMax is returned when
+// the sum exceeds MaxAmount.
+func (a Amount) Add(b Amount) (Amount, error) {
+	if err := a.check(); err != nil {
+		return 0, err
+	}
+	if err := b.check(); err != nil {
+		return 0, err
+	}
+	sum := a + b
+	if err := sum.check(); err != nil {
+		return 0, err
+	}
+	return sum, nil
+}
+
+// Sub returns the difference of the amount and the passed amount.  Both
+// amounts must be valid as determined by IsValid, and ErrNegativeAmount is
+// returned when the passed amount is greater than the amount.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if err := a.check(); err != nil {
+		return 0, err
+	}
+	if err := b.check(); err != nil {
+		return 0, err
+	}
+	if b > a {
+		return 0, ErrNegativeAmount
+	}
+	return a - b, nil
+}
+
+// MulInt returns the amount multiplied by the passed non-negative integer.  The
+// amount must be valid as determined by IsValid, and ErrAmountExceedsMax is
+// returned when the product exceeds MaxAmount.
+func (a Amount) MulInt(n int64) (Amount, error) {
+	if err := a.check(); err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, ErrNegativeAmount
+	}
+	if a != 0 && n > int64(MaxAmount/a) {
+		return 0, ErrAmountExceedsMax
+	}
+	return a * Amount(n), nil
+}
+
+// DivInt returns the amount divided by the passed positive integer, rounded
+// down to a whole atom.  The amount must be valid as determined by IsValid.
+func (a Amount) DivInt(n int64) (Amount, error) {
+	if err := a.check(); err != nil {
+		return 0, err
+	}
+	switch {
+	case n == 0:
+		return 0, ErrAmountDivideByZero
+	case n < 0:
+		return 0, ErrNegativeAmount
+	}
+	return a / Amount(n), nil
+}
+
+// SumAmounts returns the sum of the passed amounts.  Every amount must be valid
+// as determined by IsValid, and ErrAmountExceedsMax is returned as soon as
+// the running total exceeds MaxAmount.
+func SumAmounts(amounts ...Amount) (Amount, error) {
+	var sum Amount
+	for _, amount := range amounts {
+		var err error
+		sum, err = sum.Add(amount)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return sum, nil
+}
+
 // AmountSorter implements sort.Interface to allow a slice of Amounts to
 // be sorted.
 type AmountSorter []Amount
@@ -204,6 +204,366 @@ func TestAmountUnitConversions(t *testing.T) {
 	}
 }
 
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		unit   AmountUnit
+		amount Amount
+		err    error
+	}{
+		{
+			name:   "default unit",
+			s:      "1.5",
+			unit:   AmountCoin,
+			amount: 150000000,
+		},
+		{
+			name:   "no float rounding",
+			s:      "0.1",
+			unit:   AmountCoin,
+			amount: 10000000,
+		},
+		{
+			name:   "unit suffix overrides default",
+			s:      "1.5 mABC",
+			unit:   AmountCoin,
+			amount: 150000,
+		},
+		{
+			name:   "suffix without space",
+			s:      "-20ABC",
+			unit:   AmountAtom,
+			amount: -2000000000,
+		},
+		{
+			name:   "micro coin",
+			s:      "12.34 μABC",
+			unit:   AmountCoin,
+			amount: 1234,
+		},
+		{
+			name:   "ascii micro coin",
+			s:      "12.34 uABC",
+			unit:   AmountCoin,
+			amount: 1234,
+		},
+		{
+			name:   "mega coin",
+			s:      "21 MABC",
+			unit:   AmountCoin,
+			amount: MaxAmount,
+		},
+		{
+			name:   "atoms",
+			s:      "+42 Atoms",
+			unit:   AmountCoin,
+			amount: 42,
+		},
+		{
+			name:   "max int64",
+			s:      "92233720368.54775807",
+			unit:   AmountCoin,
+			amount: 9223372036854775807,
+		},
+		{
+			name:   "insignificant zeros",
+			s:      " 1.000000000000 ",
+			unit:   AmountCoin,
+			amount: 100000000,
+		},
+		{
+			name:   "leading decimal point",
+			s:      ".5",
+			unit:   AmountCoin,
+			amount: 50000000,
+		},
+		{
+			name: "excess precision",
+			s:    "0.000000001",
+			unit: AmountCoin,
+			err:  ErrInvalidAmount,
+		},
+		{
+			name: "fractional atom",
+			s:    "1.5 Atom",
+			unit: AmountCoin,
+			err:  ErrInvalidAmount,
+		},
+		{
+			name: "overflow",
+			s:    "92233720368.54775808",
+			unit: AmountCoin,
+			err:  ErrInvalidAmount,
+		},
+		{
+			name:   "largest unit",
+			s:      "1 1e10 ABC",
+			unit:   AmountCoin,
+			amount: 1e18,
+		},
+		{
+			name:   "leading zeros",
+			s:      "0000000000000000000001",
+			unit:   AmountAtom,
+			amount: 1,
+		},
+		{
+			name: "huge unit exponent",
+			s:    "1 1e300000000 ABC",
+			unit: AmountCoin,
+			err:  ErrUnknownAmountUnit,
+		},
+		{
+			name: "unit too large",
+			s:    "1",
+			unit: AmountUnit(300000000),
+			err:  ErrInvalidAmount,
+		},
+		{
+			name: "too many integer digits",
+			s:    "10000000000",
+			unit: AmountUnit(10),
+			err:  ErrInvalidAmount,
+		},
+		{
+			name: "multiple decimal points",
+			s:    "1.2.3",
+			unit: AmountCoin,
+			err:  ErrInvalidAmount,
+		},
+		{
+			name: "exponent",
+			s:    "1e3",
+			unit: AmountCoin,
+			err:  ErrUnknownAmountUnit,
+		},
+		{
+			name: "unknown unit",
+			s:    "1 BTC",
+			unit: AmountCoin,
+			err:  ErrUnknownAmountUnit,
+		},
+		{
+			name: "empty",
+			s:    "",
+			unit: AmountCoin,
+			err:  ErrInvalidAmount,
+		},
+		{
+			name: "sign only",
+			s:    "-",
+			unit: AmountCoin,
+			err:  ErrInvalidAmount,
+		},
+	}
+
+	for _, test := range tests {
+		a, err := ParseAmount(test.s, test.unit)
+		if err != test.err {
+			t.Errorf("%v: mismatched error -- got %v, want %v", test.name,
+				err, test.err)
+			continue
+		}
+		if a != test.amount {
+			t.Errorf("%v: parsed amount %d does not match expected %d",
+				test.name, a, test.amount)
+		}
+	}
+}
+
+func TestAmountFormatExact(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		unit   AmountUnit
+		flags  AmountFormatFlags
+		s      string
+	}{
+		{
+			name:   "fixed decimals",
+			amount: 150000000,
+			unit:   AmountCoin,
+			s:      "1.50000000 ABC",
+		},
+		{
+			name:   "trim zeros",
+			amount: 150000000,
+			unit:   AmountCoin,
+			flags:  AmountTrimZeros,
+			s:      "1.5 ABC",
+		},
+		{
+			name:   "trim all fractional digits",
+			amount: 100000000,
+			unit:   AmountCoin,
+			flags:  AmountTrimZeros,
+			s:      "1 ABC",
+		},
+		{
+			name:   "thousands",
+			amount: 123456789012345678,
+			unit:   AmountCoin,
+			flags:  AmountThousands | AmountTrimZeros,
+			s:      "1,234,567,890.12345678 ABC",
+		},
+		{
+			name:   "large value without float rounding",
+			amount: 9223372036854775807,
+			unit:   AmountCoin,
+			flags:  AmountOmitUnit,
+			s:      "92233720368.54775807",
+		},
+		{
+			name:   "min value",
+			amount: -9223372036854775808,
+			unit:   AmountAtom,
+			flags:  AmountThousands,
+			s:      "-9,223,372,036,854,775,808 Atom",
+		},
+		{
+			name:   "negative fraction",
+			amount: -1,
+			unit:   AmountMilliCoin,
+			s:      "-0.00001 mABC",
+		},
+		{
+			name:   "unit smaller than an atom",
+			amount: 12345,
+			unit:   AmountUnit(-10),
+			flags:  AmountThousands,
+			s:      "12345e2 1e-10 ABC",
+		},
+		{
+			name:   "unit larger than the largest parsed unit",
+			amount: -12345,
+			unit:   AmountUnit(11),
+			flags:  AmountTrimZeros | AmountOmitUnit,
+			s:      "-12345e-19",
+		},
+		{
+			name:   "min unit",
+			amount: 12345,
+			unit:   AmountUnit(math.MinInt32),
+			flags:  AmountOmitUnit,
+			s:      "12345e2147483640",
+		},
+		{
+			name:   "max unit",
+			amount: 12345,
+			unit:   AmountUnit(math.MaxInt32),
+			flags:  AmountOmitUnit,
+			s:      "12345e-2147483655",
+		},
+		{
+			name:   "zero in an extreme unit",
+			amount: 0,
+			unit:   AmountUnit(math.MaxInt32 - 7),
+			flags:  AmountOmitUnit,
+			s:      "0",
+		},
+		{
+			name:   "zero",
+			amount: 0,
+			unit:   AmountMegaCoin,
+			flags:  AmountTrimZeros | AmountOmitUnit,
+			s:      "0",
+		},
+	}
+
+	for _, test := range tests {
+		s := test.amount.FormatExact(test.unit, test.flags)
+		if s != test.s {
+			t.Errorf("%v: format '%v' does not match expected '%v'",
+				test.name, s, test.s)
+			continue
+		}
+
+		// Ensure the formatted amount parses back to the same amount
+		// when it is not grouped and uses a known unit.
+		if test.flags&(AmountThousands|AmountOmitUnit) != 0 ||
+			test.unit < AmountAtom || test.unit > AmountUnit(10) {
+
+			continue
+		}
+		a, err := ParseAmount(s, AmountCoin)
+		if err != nil || a != test.amount {
+			t.Errorf("%v: ParseAmount(%q) = %d (%v), want %d", test.name,
+				s, a, err, test.amount)
+		}
+	}
+}
+
+func TestAmountFormatLocale(t *testing.T) {
+	european := AmountLocale{Decimal: ",", Group: "."}
+	swiss := AmountLocale{Decimal: ".", Group: "'"}
+	tests := []struct {
+		name   string
+		amount Amount
+		unit   AmountUnit
+		flags  AmountFormatFlags
+		loc    AmountLocale
+		s      string
+	}{
+		{
+			name:   "european grouping",
+			amount: 123450000000,
+			unit:   AmountCoin,
+			flags:  AmountThousands | AmountTrimZeros,
+			loc:    european,
+			s:      "1.234,5 ABC",
+		},
+		{
+			name:   "european without grouping",
+			amount: 123450000000,
+			unit:   AmountCoin,
+			flags:  AmountTrimZeros,
+			loc:    european,
+			s:      "1234,5 ABC",
+		},
+		{
+			name:   "multi-byte separators",
+			amount: -123456789012345678,
+			unit:   AmountCoin,
+			flags:  AmountThousands | AmountOmitUnit,
+			loc:    AmountLocale{Decimal: "٫", Group: "\u00a0"},
+			s:      "-1\u00a0234\u00a0567\u00a0890٫12345678",
+		},
+		{
+			name:   "swiss fixed decimals",
+			amount: 100000000000,
+			unit:   AmountCoin,
+			flags:  AmountThousands,
+			loc:    swiss,
+			s:      "1'000.00000000 ABC",
+		},
+		{
+			name:   "no group separator",
+			amount: 100000000000,
+			unit:   AmountAtom,
+			flags:  AmountThousands,
+			loc:    AmountLocale{Decimal: ","},
+			s:      "100000000000 Atom",
+		},
+		{
+			name:   "default decimal separator",
+			amount: 150000,
+			unit:   AmountMilliCoin,
+			flags:  AmountTrimZeros,
+			loc:    AmountLocale{},
+			s:      "1.5 mABC",
+		},
+	}
+
+	for _, test := range tests {
+		s := test.amount.FormatLocale(test.unit, test.flags, test.loc)
+		if s != test.s {
+			t.Errorf("%v: format '%v' does not match expected '%v'",
+				test.name, s, test.s)
+		}
+	}
+}
+
 func TestAmountMulF64(t *testing.T) {
 	tests := []struct {
 		name string
@@ -311,6 +671,134 @@ func TestAmountMulF64(t *testing.T) {
 	}
 }
 
+func TestAmountArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func() (Amount, error)
+		res  Amount
+		err  error
+	}{
+		{
+			name: "add",
+			f:    func() (Amount, error) { return Amount(1e8).Add(2e8) },
+			res:  3e8,
+		},
+		{
+			name: "add to max",
+			f:    func() (Amount, error) { return Amount(MaxAmount - 1).Add(1) },
+			res:  MaxAmount,
+		},
+		{
+			name: "add exceeds max",
+			f:    func() (Amount, error) { return Amount(MaxAmount).Add(1) },
+			err:  ErrAmountExceedsMax,
+		},
+		{
+			name: "add negative operand",
+			f:    func() (Amount, error) { return Amount(5).Add(-1) },
+			err:  ErrNegativeAmount,
+		},
+		{
+			name: "add invalid operand",
+			f:    func() (Amount, error) { return Amount(MaxAmount + 1).Add(0) },
+			err:  ErrAmountExceedsMax,
+		},
+		{
+			name: "sub",
+			f:    func() (Amount, error) { return Amount(3e8).Sub(1e8) },
+			res:  2e8,
+		},
+		{
+			name: "sub to zero",
+			f:    func() (Amount, error) { return Amount(1e8).Sub(1e8) },
+			res:  0,
+		},
+		{
+			name: "sub negative result",
+			f:    func() (Amount, error) { return Amount(1e8).Sub(1e8 + 1) },
+			err:  ErrNegativeAmount,
+		},
+		{
+			name: "mul",
+			f:    func() (Amount, error) { return Amount(1e8).MulInt(21e6) },
+			res:  MaxAmount,
+		},
+		{
+			name: "mul zero",
+			f:    func() (Amount, error) { return Amount(0).MulInt(1 << 62) },
+			res:  0,
+		},
+		{
+			name: "mul exceeds max",
+			f:    func() (Amount, error) { return Amount(1e8).MulInt(21e6 + 1) },
+			err:  ErrAmountExceedsMax,
+		},
+		{
+			name: "mul would overflow int64",
+			f:    func() (Amount, error) { return Amount(MaxAmount).MulInt(1 << 62) },
+			err:  ErrAmountExceedsMax,
+		},
+		{
+			name: "mul negative",
+			f:    func() (Amount, error) { return Amount(1).MulInt(-1) },
+			err:  ErrNegativeAmount,
+		},
+		{
+			name: "div rounds down",
+			f:    func() (Amount, error) { return Amount(100).DivInt(3) },
+			res:  33,
+		},
+		{
+			name: "div by zero",
+			f:    func() (Amount, error) { return Amount(100).DivInt(0) },
+			err:  ErrAmountDivideByZero,
+		},
+		{
+			name: "div negative",
+			f:    func() (Amount, error) { return Amount(100).DivInt(-2) },
+			err:  ErrNegativeAmount,
+		},
+		{
+			name: "sum",
+			f:    func() (Amount, error) { return SumAmounts(1, 2, 3) },
+			res:  6,
+		},
+		{
+			name: "sum empty",
+			f:    func() (Amount, error) { return SumAmounts() },
+			res:  0,
+		},
+		{
+			name: "sum exceeds max",
+			f: func() (Amount, error) {
+				return SumAmounts(MaxAmount/2, MaxAmount/2, MaxAmount/2)
+			},
+			err: ErrAmountExceedsMax,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := test.f()
+		if err != test.err {
+			t.Errorf("%v: mismatched error -- got %v, want %v", test.name,
+				err, test.err)
+			continue
+		}
+		if res != test.res {
+			t.Errorf("%v: expected %v got %v", test.name, test.res, res)
+		}
+		if err == nil && !res.IsValid() {
+			t.Errorf("%v: result %v is not valid", test.name, res)
+		}
+	}
+
+	for _, a := range []Amount{-1, MaxAmount + 1} {
+		if a.IsValid() {
+			t.Errorf("IsValid: amount %d is unexpectedly valid", a)
+		}
+	}
+}
+
 func TestAmountSorter(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,188 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultTicker is the ticker of the coin used by the default amount unit
+// registry.
+const DefaultTicker = "ABC"
+
+// ErrDuplicateAmountUnit describes an error where a unit name is registered
+// with an AmountUnitRegistry for a unit other than the one it already names.
+var ErrDuplicateAmountUnit = errors.New("duplicate amount unit name")
+
+// AmountUnitRegistry maps amount units to and from their names for a coin
+// with a specific ticker.  Each unit has a display name, which is the first
+// name registered for it, and may have any number of alternative names which
+// are only used for parsing.  It is safe for concurrent access.
+type AmountUnitRegistry struct {
+	mtx    sync.RWMutex
+	ticker string
+	names  map[AmountUnit]string
+	byName map[string]AmountUnit
+}
+
+// NewAmountUnitRegistry returns a new amount unit registry for a coin with the
+// passed ticker.  The registry contains the names of the standard units formed
+// from the ticker with SI prefixes, such as "mABC" for AmountMilliCoin when the
+// ticker is "ABC", along with "Atom" for AmountAtom.  "uABC" is registered as
+// an ASCII alternative for AmountMicroCoin and "Atoms" as the plural of
+// AmountAtom.
+func NewAmountUnitRegistry(ticker string) *AmountUnitRegistry {
+	r := &AmountUnitRegistry{
+		ticker: ticker,
+		names:  make(map[AmountUnit]string),
+		byName: make(map[string]AmountUnit),
+	}
+	standard := []struct {
+		unit  AmountUnit
+		names []string
+	}{
+		{AmountMegaCoin, []string{"M" + ticker}},
+		{AmountKiloCoin, []string{"k" + ticker}},
+		{AmountCoin, []string{ticker}},
+		{AmountMilliCoin, []string{"m" + ticker}},
+		{AmountMicroCoin, []string{"μ" + ticker, "u" + ticker}},
+		{AmountAtom, []string{"Atom", "Atoms"}},
+	}
+	for _, s := range standard {
+		for _, name := range s.names {
+			// The standard names are distinct for any ticker
+			// which does not itself collide with them, in which
+			// case the first name is kept.
+			r.Register(s.unit, name)
+		}
+	}
+	return r
+}
+
+// Ticker returns the ticker of the coin the registry is for.
+func (r *AmountUnitRegistry) Ticker() string {
+	return r.ticker
+}
+
+// Register adds the passed name for the passed unit.  The first name
+// registered for a unit is its display name.  Registering a name again for the
+// same unit has no effect, while ErrDuplicateAmountUnit is returned when the
+// name is already registered for a different unit.
+func (r *AmountUnitRegistry) Register(u AmountUnit, name string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if existing, ok := r.byName[name]; ok {
+		if existing != u {
+			return ErrDuplicateAmountUnit
+		}
+		return nil
+	}
+	r.byName[name] = u
+	if _, ok := r.names[u]; !ok {
+		r.names[u] = name
+	}
+	return nil
+}
+
+// UnitString returns the display name of the passed unit.  For units without a
+// registered name, "1eN <ticker>" is returned, where N is the AmountUnit.
+func (r *AmountUnitRegistry) UnitString(u AmountUnit) string {
+	r.mtx.RLock()
+	name, ok := r.names[u]
+	r.mtx.RUnlock()
+	if ok {
+		return name
+	}
+	return "1e" + strconv.FormatInt(int64(u), 10) + " " + r.ticker
+}
+
+// ParseUnit returns the unit with the passed name, which may be any name
+// registered for it or the "1eN <ticker>" form returned by UnitString.  The
+// exponent N of the latter form must be between -8 and 10, the units in which
+// a single unit is a whole number of atoms that fits in an Amount.
+// ErrUnknownAmountUnit is returned when the name is not recognized.
+func (r *AmountUnitRegistry) ParseUnit(name string) (AmountUnit, error) {
+	name = strings.TrimSpace(name)
+	r.mtx.RLock()
+	u, ok := r.byName[name]
+	r.mtx.RUnlock()
+	if ok {
+		return u, nil
+	}
+
+	exp := strings.TrimSuffix(name, " "+r.ticker)
+	if exp == name || !strings.HasPrefix(exp, "1e") {
+		return 0, ErrUnknownAmountUnit
+	}
+	n, err := strconv.ParseInt(exp[len("1e"):], 10, 8)
+	if err != nil || n < int64(minAmountUnit) || n > int64(maxAmountUnit) {
+		return 0, ErrUnknownAmountUnit
+	}
+	return AmountUnit(n), nil
+}
+
+var (
+	// amountUnitsMtx protects amountUnits.
+	amountUnitsMtx sync.RWMutex
+
+	// amountUnits is the registry used to name and parse amount units
+	// throughout the package.
+	amountUnits = NewAmountUnitRegistry(DefaultTicker)
+)
+
+// AmountUnits returns the amount unit registry used by AmountUnit.String,
+// ParseAmountUnit, ParseAmount, and the functions which format amounts.
+func AmountUnits() *AmountUnitRegistry {
+	amountUnitsMtx.RLock()
+	r := amountUnits
+	amountUnitsMtx.RUnlock()
+	return r
+}
+
+// SetAmountUnits replaces the amount unit registry used by AmountUnit.String,
+// ParseAmountUnit, ParseAmount, and the functions which format amounts.  This
+// allows forks of the chain to format and parse amounts with their own ticker
+// and unit names.  It is typically called once during initialization.
+func SetAmountUnits(r *AmountUnitRegistry) {
+	amountUnitsMtx.Lock()
+	amountUnits = r
+	amountUnitsMtx.Unlock()
+}
+
+// RegisterAmountUnit adds the passed name for the passed unit to the amount
+// unit registry returned by AmountUnits.  See AmountUnitRegistry.Register for
+// details.
+func RegisterAmountUnit(u AmountUnit, name string) error {
+	return AmountUnits().Register(u, name)
+}
+
+// ParseAmountUnit returns the unit with the passed name using the amount unit
+// registry returned by AmountUnits.  See AmountUnitRegistry.ParseUnit for
+// details.
+func ParseAmountUnit(name string) (AmountUnit, error) {
+	return AmountUnits().ParseUnit(name)
+}
+
+// ConvertTo returns the exact value of the amount in the passed unit as a
+// rational number.  Unlike ToUnit, no precision is lost for any amount.  The
+// unit must be between AmountAtom and 1e10 coins, the units amounts may be
+// parsed in, and ErrUnknownAmountUnit is returned for any other unit.  The
+// exact decimal representation of the value is returned by FormatExact with
+// the AmountTrimZeros and AmountOmitUnit flags.
+func (a Amount) ConvertTo(u AmountUnit) (*big.Rat, error) {
+	if u < minAmountUnit || u > maxAmountUnit {
+		return nil, ErrUnknownAmountUnit
+	}
+
+	// An amount in the unit is the number of atoms divided by 10^(u+8).
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(u)+8), nil)
+	value := new(big.Rat).SetInt64(int64(a))
+	return value.Quo(value, new(big.Rat).SetInt(scale)), nil
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/abcsuite/abcutil"
+)
+
+// TestAmountUnitRegistry ensures units are named and parsed by a registry and
+// that unit names can not be ambiguous.
+func TestAmountUnitRegistry(t *testing.T) {
+	r := abcutil.NewAmountUnitRegistry("XYZ")
+	if ticker := r.Ticker(); ticker != "XYZ" {
+		t.Errorf("Ticker: mismatched ticker -- got %s, want XYZ", ticker)
+	}
+	if err := r.Register(abcutil.AmountUnit(-2), "cXYZ"); err != nil {
+		t.Fatalf("Register: unexpected error: %v", err)
+	}
+	if err := r.Register(abcutil.AmountUnit(-2), "centiXYZ"); err != nil {
+		t.Fatalf("Register: unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		unit abcutil.AmountUnit
+		str  string
+	}{
+		{"mega", abcutil.AmountMegaCoin, "MXYZ"},
+		{"kilo", abcutil.AmountKiloCoin, "kXYZ"},
+		{"coin", abcutil.AmountCoin, "XYZ"},
+		{"milli", abcutil.AmountMilliCoin, "mXYZ"},
+		{"micro", abcutil.AmountMicroCoin, "μXYZ"},
+		{"atom", abcutil.AmountAtom, "Atom"},
+		{"registered", abcutil.AmountUnit(-2), "cXYZ"},
+		{"unregistered", abcutil.AmountUnit(-7), "1e-7 XYZ"},
+		{"unregistered positive", abcutil.AmountUnit(9), "1e9 XYZ"},
+		{"largest unit", abcutil.AmountUnit(10), "1e10 XYZ"},
+	}
+	for _, test := range tests {
+		if str := r.UnitString(test.unit); str != test.str {
+			t.Errorf("UnitString (%s): mismatched name -- got %s, want %s",
+				test.name, str, test.str)
+		}
+		unit, err := r.ParseUnit(test.str)
+		if err != nil {
+			t.Errorf("ParseUnit (%s): unexpected error: %v", test.name, err)
+			continue
+		}
+		if unit != test.unit {
+			t.Errorf("ParseUnit (%s): mismatched unit -- got %d, want %d",
+				test.name, unit, test.unit)
+		}
+	}
+
+	parseTests := []struct {
+		name string
+		str  string
+		unit abcutil.AmountUnit
+		err  error
+	}{
+		{"ascii micro", "uXYZ", abcutil.AmountMicroCoin, nil},
+		{"plural atoms", "Atoms", abcutil.AmountAtom, nil},
+		{"alternative name", "centiXYZ", abcutil.AmountUnit(-2), nil},
+		{"surrounding space", " mXYZ ", abcutil.AmountMilliCoin, nil},
+		{"other ticker", "ABC", 0, abcutil.ErrUnknownAmountUnit},
+		{"wrong case", "MxYZ", 0, abcutil.ErrUnknownAmountUnit},
+		{"exponent of other ticker", "1e-2 ABC", 0, abcutil.ErrUnknownAmountUnit},
+		{"malformed exponent", "1ex XYZ", 0, abcutil.ErrUnknownAmountUnit},
+		{"fraction of an atom", "1e-9 XYZ", 0, abcutil.ErrUnknownAmountUnit},
+		{"exponent too large", "1e11 XYZ", 0, abcutil.ErrUnknownAmountUnit},
+		{"huge exponent", "1e300000000 XYZ", 0, abcutil.ErrUnknownAmountUnit},
+		{"empty", "", 0, abcutil.ErrUnknownAmountUnit},
+	}
+	for _, test := range parseTests {
+		unit, err := r.ParseUnit(test.str)
+		if err != test.err {
+			t.Errorf("ParseUnit (%s): mismatched error -- got %v, want %v",
+				test.name, err, test.err)
+			continue
+		}
+		if unit != test.unit {
+			t.Errorf("ParseUnit (%s): mismatched unit -- got %d, want %d",
+				test.name, unit, test.unit)
+		}
+	}
+
+	if err := r.Register(abcutil.AmountCoin, "mXYZ"); err != abcutil.ErrDuplicateAmountUnit {
+		t.Errorf("Register: mismatched error -- got %v, want %v", err,
+			abcutil.ErrDuplicateAmountUnit)
+	}
+	if err := r.Register(abcutil.AmountMilliCoin, "mXYZ"); err != nil {
+		t.Errorf("Register: unexpected error re-registering name: %v", err)
+	}
+}
+
+// TestSetAmountUnits ensures replacing the package amount unit registry changes
+// how amounts are formatted and parsed.
+func TestSetAmountUnits(t *testing.T) {
+	defaultUnits := abcutil.AmountUnits()
+	defer abcutil.SetAmountUnits(defaultUnits)
+
+	if s := abcutil.Amount(150000).Format(abcutil.AmountMilliCoin); s != "1.5 mABC" {
+		t.Errorf("Format: mismatched default format -- got %s", s)
+	}
+
+	abcutil.SetAmountUnits(abcutil.NewAmountUnitRegistry("TABC"))
+	if s := abcutil.Amount(150000).Format(abcutil.AmountMilliCoin); s != "1.5 mTABC" {
+		t.Errorf("Format: mismatched format -- got %s, want 1.5 mTABC", s)
+	}
+	if s := abcutil.FeeRate(10000).String(); s != "0.0001 TABC/kB" {
+		t.Errorf("String: mismatched fee rate -- got %s, want "+
+			"0.0001 TABC/kB", s)
+	}
+	amount, err := abcutil.ParseAmount("2.5 kTABC", abcutil.AmountCoin)
+	if err != nil || amount != 2500e8 {
+		t.Errorf("ParseAmount: got %d (%v), want %d", amount, err,
+			abcutil.Amount(2500e8))
+	}
+	if _, err := abcutil.ParseAmount("1 ABC", abcutil.AmountCoin); err != abcutil.ErrUnknownAmountUnit {
+		t.Errorf("ParseAmount: mismatched error -- got %v, want %v", err,
+			abcutil.ErrUnknownAmountUnit)
+	}
+
+	if err := abcutil.RegisterAmountUnit(abcutil.AmountAtom, "sats"); err != nil {
+		t.Fatalf("RegisterAmountUnit: unexpected error: %v", err)
+	}
+	unit, err := abcutil.ParseAmountUnit("sats")
+	if err != nil || unit != abcutil.AmountAtom {
+		t.Errorf("ParseAmountUnit: got %v (%v), want %v", unit, err,
+			abcutil.AmountAtom)
+	}
+	if _, err := defaultUnits.ParseUnit("sats"); err != abcutil.ErrUnknownAmountUnit {
+		t.Errorf("ParseUnit: name registered with the wrong registry")
+	}
+}
+
+// TestAmountConvertTo ensures amounts are converted to other units exactly.
+func TestAmountConvertTo(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount abcutil.Amount
+		unit   abcutil.AmountUnit
+		want   string
+	}{
+		{"coin", 150000000, abcutil.AmountCoin, "3/2"},
+		{"atom", 150000000, abcutil.AmountAtom, "150000000"},
+		{"one atom in coins", 1, abcutil.AmountCoin, "1/100000000"},
+		{"one atom in megacoins", 1, abcutil.AmountMegaCoin, "1/100000000000000"},
+		{"max int64 in coins", 9223372036854775807, abcutil.AmountCoin,
+			"9223372036854775807/100000000"},
+		{"negative", -123456, abcutil.AmountMilliCoin, "-3858/3125"},
+		{"largest unit", 12345, abcutil.AmountUnit(10), "2469/200000000000000000"},
+		{"zero", 0, abcutil.AmountKiloCoin, "0"},
+	}
+
+	for _, test := range tests {
+		want, ok := new(big.Rat).SetString(test.want)
+		if !ok {
+			t.Fatalf("%s: invalid rational %q", test.name, test.want)
+		}
+		got, err := test.amount.ConvertTo(test.unit)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if got.Cmp(want) != 0 {
+			t.Errorf("%s: mismatched value -- got %v, want %v", test.name,
+				got.RatString(), want.RatString())
+		}
+	}
+
+	// The rational round trips through its exact decimal representation.
+	amount := abcutil.Amount(9223372036854775807)
+	rat, err := amount.ConvertTo(abcutil.AmountCoin)
+	if err != nil {
+		t.Fatalf("ConvertTo: unexpected error: %v", err)
+	}
+	decimal := rat.FloatString(8)
+	if want := amount.FormatExact(abcutil.AmountCoin, abcutil.AmountOmitUnit); decimal != want {
+		t.Errorf("FloatString: mismatched decimal -- got %s, want %s",
+			decimal, want)
+	}
+}
+
+// TestAmountConvertToInvalidUnit ensures amounts are not converted to units
+// outside of those amounts may be parsed in.
+func TestAmountConvertToInvalidUnit(t *testing.T) {
+	units := []abcutil.AmountUnit{
+		abcutil.AmountUnit(-9),
+		abcutil.AmountUnit(11),
+		abcutil.AmountUnit(math.MinInt32),
+		abcutil.AmountUnit(math.MaxInt32),
+	}
+	for _, u := range units {
+		_, err := abcutil.Amount(12345).ConvertTo(u)
+		if err != abcutil.ErrUnknownAmountUnit {
+			t.Errorf("ConvertTo(%d): unexpected error -- got %v, want %v",
+				u, err, abcutil.ErrUnknownAmountUnit)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// ErrUnknownAmountEncoding describes an error where an AmountValue has an
+// encoding which is not one of the defined AmountEncoding values.
+var ErrUnknownAmountEncoding = errors.New("unknown amount encoding")
+
+// AmountEncoding selects how an AmountValue is encoded as text and JSON.
+type AmountEncoding uint8
+
+const (
+	// AmountEncodingAtoms encodes an amount as an integer number of atoms,
+	// such as 150000000.  This is the encoding of a bare Amount as text and
+	// JSON.
+	AmountEncodingAtoms AmountEncoding = iota
+
+	// AmountEncodingCoin encodes an amount as an exact decimal number of
+	// coins, such as "1.5".  Amounts are always quoted strings in JSON so
+	// no precision is lost by decoders which use floating point numbers.
+	AmountEncodingCoin
+)
+
+// String returns the encoding as a human-readable string.
+func (e AmountEncoding) String() string {
+	switch e {
+	case AmountEncodingAtoms:
+		return "atoms"
+	case AmountEncodingCoin:
+		return "coin"
+	}
+	return "unknown (" + strconv.FormatUint(uint64(e), 10) + ")"
+}
+
+// parseAtoms parses the passed string as a signed integer number of atoms.
+func parseAtoms(s string) (Amount, error) {
+	atoms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	return Amount(atoms), nil
+}
+
+// unmarshalAmountJSON decodes an amount from either a JSON number of atoms or
+// a JSON string of coins.
+func unmarshalAmountJSON(data []byte) (Amount, error) {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return 0, err
+		}
+		return ParseAmount(s, AmountCoin)
+	}
+	return parseAtoms(string(data))
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.  The amount is
+// encoded as an integer number of atoms, the same as its JSON encoding, so
+// amounts used as the keys of JSON objects keep the encoding of the underlying
+// int64.  Use an AmountValue to encode an amount as coins.
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(a), 10)), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.  The text
+// must be an integer number of atoms.
+func (a *Amount) UnmarshalText(text []byte) error {
+	amount, err := parseAtoms(string(text))
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+// MarshalJSON satisfies the json.Marshaler interface.  The amount is encoded as
+// an integer number of atoms, which is the same as the encoding of the
+// underlying int64.  Use an AmountValue to select a different encoding.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(a), 10)), nil
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface.  Both encodings
+// described by AmountEncoding are accepted, that is, an integer number of
+// atoms or a string which is parsed with ParseAmount in AmountCoin.  Numbers
+// with a fractional part or exponent are rejected with ErrInvalidAmount.  JSON
+// null leaves the amount unchanged.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	amount, err := unmarshalAmountJSON(data)
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+// AmountValue wraps an Amount with the encoding to use when it is encoded as
+// text or JSON so APIs may choose between atoms and coins.  It implements the
+// encoding.TextMarshaler and json.Marshaler interfaces along with their
+// unmarshalling counterparts.
+//
+// Text and JSON are encoded and decoded strictly in the selected encoding
+// since a bare number is ambiguous.  Only a bare Amount accepts either encoding
+// when decoding JSON, as described by Amount.UnmarshalJSON.
+type AmountValue struct {
+	// Amount is the wrapped amount.
+	Amount Amount
+
+	// Encoding is the encoding to use.
+	Encoding AmountEncoding
+}
+
+// NewAmountValue returns a new AmountValue which wraps the passed amount and
+// uses the passed encoding.
+func NewAmountValue(a Amount, e AmountEncoding) *AmountValue {
+	return &AmountValue{Amount: a, Encoding: e}
+}
+
+// encode returns the string encoding of the wrapped amount in the selected
+// encoding.
+func (v *AmountValue) encode() (string, error) {
+	switch v.Encoding {
+	case AmountEncodingAtoms:
+		return strconv.FormatInt(int64(v.Amount), 10), nil
+	case AmountEncodingCoin:
+		return formatDecimalAmount(v.Amount, AmountCoin, AmountTrimZeros,
+			defaultAmountLocale), nil
+	}
+	return "", ErrUnknownAmountEncoding
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (v AmountValue) MarshalText() ([]byte, error) {
+	s, err := v.encode()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.  The
+// Encoding field must be set to the expected encoding beforehand.
+func (v *AmountValue) UnmarshalText(text []byte) error {
+	var amount Amount
+	var err error
+	switch v.Encoding {
+	case AmountEncodingAtoms:
+		amount, err = parseAtoms(string(text))
+	case AmountEncodingCoin:
+		amount, err = ParseAmount(string(text), AmountCoin)
+	default:
+		err = ErrUnknownAmountEncoding
+	}
+	if err != nil {
+		return err
+	}
+	v.Amount = amount
+	return nil
+}
+
+// MarshalJSON satisfies the json.Marshaler interface.  Amounts of coins are
+// encoded as JSON strings and amounts of atoms as JSON numbers.
+func (v AmountValue) MarshalJSON() ([]byte, error) {
+	s, err := v.encode()
+	if err != nil {
+		return nil, err
+	}
+	if v.Encoding == AmountEncodingCoin {
+		return json.Marshal(s)
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface.  The Encoding field
+// must be set to the expected encoding beforehand, and the amount must be a
+// JSON number of atoms or a JSON string of coins accordingly.  Any other JSON
+// value is rejected with ErrInvalidAmount, so a number is never mistaken for
+// coins.  JSON null leaves the amount unchanged.
+func (v *AmountValue) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	quoted := len(data) > 0 && data[0] == '"'
+	switch v.Encoding {
+	case AmountEncodingAtoms:
+		if quoted {
+			return ErrInvalidAmount
+		}
+	case AmountEncodingCoin:
+		if !quoted {
+			return ErrInvalidAmount
+		}
+	default:
+		return ErrUnknownAmountEncoding
+	}
+	amount, err := unmarshalAmountJSON(data)
+	if err != nil {
+		return err
+	}
+	v.Amount = amount
+	return nil
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/abcsuite/abcutil"
+)
+
+// TestAmountJSON ensures a bare Amount is encoded as atoms and decodes from
+// both atoms and coins.
+func TestAmountJSON(t *testing.T) {
+	type payment struct {
+		Amount abcutil.Amount `json:"amount"`
+	}
+	encoded, err := json.Marshal(payment{Amount: 150000000})
+	if err != nil {
+		t.Fatalf("Marshal: unexpected error: %v", err)
+	}
+	if want := `{"amount":150000000}`; string(encoded) != want {
+		t.Errorf("Marshal: mismatched encoding -- got %s, want %s",
+			encoded, want)
+	}
+
+	tests := []struct {
+		name   string
+		json   string
+		amount abcutil.Amount
+		err    error
+	}{
+		{"atoms", `150000000`, 150000000, nil},
+		{"negative atoms", `-1`, -1, nil},
+		{"coins", `"1.5"`, 150000000, nil},
+		{"coins with unit", `"1.5 mABC"`, 150000, nil},
+		{"all atoms of max int64 coins", `"92233720368.54775807"`,
+			9223372036854775807, nil},
+		{"null", `null`, 42, nil},
+		{"fractional number", `1.5`, 0, abcutil.ErrInvalidAmount},
+		{"exponent", `1e8`, 0, abcutil.ErrInvalidAmount},
+		{"too precise coins", `"0.000000001"`, 0, abcutil.ErrInvalidAmount},
+		{"unknown unit", `"1 XYZ"`, 0, abcutil.ErrUnknownAmountUnit},
+	}
+	for _, test := range tests {
+		amount := abcutil.Amount(42)
+		err := json.Unmarshal([]byte(test.json), &amount)
+		if err != test.err {
+			t.Errorf("Unmarshal (%s): mismatched error -- got %v, want %v",
+				test.name, err, test.err)
+			continue
+		}
+		if test.err == nil && amount != test.amount {
+			t.Errorf("Unmarshal (%s): mismatched amount -- got %d, "+
+				"want %d", test.name, amount, test.amount)
+		}
+	}
+}
+
+// TestAmountText ensures a bare Amount round trips through its text encoding
+// as atoms, including when it is used as the key of a JSON object.
+func TestAmountText(t *testing.T) {
+	amount := abcutil.Amount(-123456789)
+	text, err := amount.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText: unexpected error: %v", err)
+	}
+	if want := "-123456789"; string(text) != want {
+		t.Errorf("MarshalText: mismatched text -- got %s, want %s", text,
+			want)
+	}
+	var decoded abcutil.Amount
+	if err := decoded.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText: unexpected error: %v", err)
+	}
+	if decoded != amount {
+		t.Errorf("UnmarshalText: mismatched amount -- got %d, want %d",
+			decoded, amount)
+	}
+	for _, text := range []string{"1.5", "1 ABC", ""} {
+		err := decoded.UnmarshalText([]byte(text))
+		if err != abcutil.ErrInvalidAmount {
+			t.Errorf("UnmarshalText(%q): mismatched error -- got %v, "+
+				"want %v", text, err, abcutil.ErrInvalidAmount)
+		}
+	}
+
+	// Amounts used as map keys are encoded as atoms just like the keys of
+	// the underlying int64.
+	amounts := map[abcutil.Amount]int{150000000: 1, -5: 2}
+	encoded, err := json.Marshal(amounts)
+	if err != nil {
+		t.Fatalf("Marshal: unexpected error: %v", err)
+	}
+	if want := `{"-5":2,"150000000":1}`; string(encoded) != want {
+		t.Errorf("Marshal: mismatched map -- got %s, want %s", encoded,
+			want)
+	}
+	var decodedAmounts map[abcutil.Amount]int
+	if err := json.Unmarshal(encoded, &decodedAmounts); err != nil {
+		t.Fatalf("Unmarshal: unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decodedAmounts, amounts) {
+		t.Errorf("Unmarshal: mismatched map -- got %v, want %v",
+			decodedAmounts, amounts)
+	}
+}
+
+// TestAmountValue ensures amounts round trip through the text and JSON
+// encodings of AmountValue in each encoding.
+func TestAmountValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   abcutil.Amount
+		encoding abcutil.AmountEncoding
+		text     string
+		json     string
+	}{
+		{
+			name:     "atoms",
+			amount:   150000000,
+			encoding: abcutil.AmountEncodingAtoms,
+			text:     "150000000",
+			json:     `150000000`,
+		},
+		{
+			name:     "coins",
+			amount:   150000000,
+			encoding: abcutil.AmountEncodingCoin,
+			text:     "1.5",
+			json:     `"1.5"`,
+		},
+		{
+			name:     "max amount in coins",
+			amount:   abcutil.MaxAmount,
+			encoding: abcutil.AmountEncodingCoin,
+			text:     "21000000",
+			json:     `"21000000"`,
+		},
+		{
+			name:     "one atom in coins",
+			amount:   1,
+			encoding: abcutil.AmountEncodingCoin,
+			text:     "0.00000001",
+			json:     `"0.00000001"`,
+		},
+	}
+
+	for _, test := range tests {
+		v := abcutil.NewAmountValue(test.amount, test.encoding)
+		text, err := v.MarshalText()
+		if err != nil {
+			t.Errorf("MarshalText (%s): unexpected error: %v", test.name,
+				err)
+			continue
+		}
+		if string(text) != test.text {
+			t.Errorf("MarshalText (%s): mismatched text -- got %s, "+
+				"want %s", test.name, text, test.text)
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			t.Errorf("MarshalJSON (%s): unexpected error: %v", test.name,
+				err)
+			continue
+		}
+		if string(encoded) != test.json {
+			t.Errorf("MarshalJSON (%s): mismatched JSON -- got %s, "+
+				"want %s", test.name, encoded, test.json)
+		}
+
+		// Decode into values with the same encoding.
+		decoded := abcutil.AmountValue{Encoding: test.encoding}
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Errorf("UnmarshalText (%s): unexpected error: %v",
+				test.name, err)
+		} else if decoded.Amount != test.amount {
+			t.Errorf("UnmarshalText (%s): mismatched amount -- got %d, "+
+				"want %d", test.name, decoded.Amount, test.amount)
+		}
+		decoded = abcutil.AmountValue{Encoding: test.encoding}
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Errorf("UnmarshalJSON (%s): unexpected error: %v",
+				test.name, err)
+		} else if decoded.Amount != test.amount {
+			t.Errorf("UnmarshalJSON (%s): mismatched amount -- got %d, "+
+				"want %d", test.name, decoded.Amount, test.amount)
+		}
+	}
+
+	// JSON is only decoded in the selected encoding, so a number is not
+	// decoded as atoms when coins are expected and vice versa.
+	jsonTests := []struct {
+		name     string
+		encoding abcutil.AmountEncoding
+		json     string
+		err      error
+	}{
+		{"number as coins", abcutil.AmountEncodingCoin, `2`,
+			abcutil.ErrInvalidAmount},
+		{"string as atoms", abcutil.AmountEncodingAtoms, `"2"`,
+			abcutil.ErrInvalidAmount},
+		{"bool as coins", abcutil.AmountEncodingCoin, `true`,
+			abcutil.ErrInvalidAmount},
+		{"unknown encoding", 2, `2`, abcutil.ErrUnknownAmountEncoding},
+	}
+	for _, test := range jsonTests {
+		decoded := abcutil.AmountValue{Amount: 1, Encoding: test.encoding}
+		err := decoded.UnmarshalJSON([]byte(test.json))
+		if err != test.err {
+			t.Errorf("UnmarshalJSON (%s): mismatched error -- got %v, "+
+				"want %v", test.name, err, test.err)
+		}
+		if decoded.Amount != 1 {
+			t.Errorf("UnmarshalJSON (%s): amount changed to %d",
+				test.name, decoded.Amount)
+		}
+	}
+	nullValue := abcutil.AmountValue{Amount: 1, Encoding: abcutil.AmountEncodingCoin}
+	if err := json.Unmarshal([]byte(`null`), &nullValue); err != nil ||
+		nullValue.Amount != 1 {
+
+		t.Errorf("UnmarshalJSON (null): got %d (%v), want 1", nullValue.Amount,
+			err)
+	}
+
+	// Text is ambiguous so it is only decoded in the selected encoding.
+	atoms := abcutil.AmountValue{Encoding: abcutil.AmountEncodingAtoms}
+	if err := atoms.UnmarshalText([]byte("1.5")); err != abcutil.ErrInvalidAmount {
+		t.Errorf("UnmarshalText: mismatched error -- got %v, want %v", err,
+			abcutil.ErrInvalidAmount)
+	}
+
+	unknown := abcutil.AmountValue{Amount: 1, Encoding: 2}
+	if _, err := unknown.MarshalText(); err != abcutil.ErrUnknownAmountEncoding {
+		t.Errorf("MarshalText: mismatched error -- got %v, want %v", err,
+			abcutil.ErrUnknownAmountEncoding)
+	}
+	if err := unknown.UnmarshalText([]byte("1")); err != abcutil.ErrUnknownAmountEncoding {
+		t.Errorf("UnmarshalText: mismatched error -- got %v, want %v", err,
+			abcutil.ErrUnknownAmountEncoding)
+	}
+}
@@ -46,6 +46,18 @@ func Decode(b string) []byte {
 	return val
 }
 
+// InvalidCharIndex returns the index of the first byte of the passed string
+// which is not part of the modified base58 alphabet, or -1 when every byte is
+// valid.  It is useful for reporting why Decode returned an empty result.
+func InvalidCharIndex(b string) int {
+	for i := 0; i < len(b); i++ {
+		if b58[b[i]] == 255 {
+			return i
+		}
+	}
+	return -1
+}
+
 // Encode encodes a byte slice to a modified base58 string.
 func Encode(b []byte) string {
 	x := new(big.Int)
@@ -31,19 +31,20 @@ var stringTests = []struct {
 }
 
 var invalidStringTests = []struct {
-	in  string
-	out string
+	in    string
+	out   string
+	index int
 }{
-	{"0", ""},
-	{"O", ""},
-	{"I", ""},
-	{"l", ""},
-	{"3mJr0", ""},
-	{"O3yxU", ""},
-	{"3sNI", ""},
-	{"4kl8", ""},
-	{"0OIl", ""},
-	{"!@#$%^&*()-_=+~`", ""},
+	{"0", "", 0},
+	{"O", "", 0},
+	{"I", "", 0},
+	{"l", "", 0},
+	{"3mJr0", "", 4},
+	{"O3yxU", "", 0},
+	{"3sNI", "", 3},
+	{"4kl8", "", 2},
+	{"0OIl", "", 0},
+	{"!@#$%^&*()-_=+~`", "", 0},
 }
 
 var hexTests = []struct {
@@ -95,5 +96,18 @@ func TestBase58(t *testing.T) {
 				x, res, test.out)
 			continue
 		}
+		if idx := base58.InvalidCharIndex(test.in); idx != test.index {
+			t.Errorf("InvalidCharIndex test #%d failed: got: %d want: %d",
+				x, idx, test.index)
+			continue
+		}
+	}
+
+	// Valid input has no invalid characters
+	for x, test := range hexTests {
+		if idx := base58.InvalidCharIndex(test.out); idx != -1 {
+			t.Errorf("InvalidCharIndex valid test #%d failed: got: %d "+
+				"want: -1", x, idx)
+		}
 	}
 }
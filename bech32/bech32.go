@@ -0,0 +1,318 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package bech32
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// charset is the set of characters used in the data section of an
+	// encoded string.  The index of each character is its 5-bit value.
+	charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// separator separates the human-readable part from the data part.
+	separator = '1'
+
+	// checksumLen is the number of 5-bit groups in the checksum.
+	checksumLen = 6
+
+	// MaxLength is the maximum length of an encoded string.
+	MaxLength = 90
+)
+
+var (
+	// ErrMixedCase describes an error where an encoded string contains
+	// both lowercase and uppercase characters.
+	ErrMixedCase = errors.New("string contains both lowercase and " +
+		"uppercase characters")
+
+	// ErrInvalidLength describes an error where an encoded string is longer
+	// than MaxLength or too short to contain a checksum.
+	ErrInvalidLength = errors.New("invalid encoded string length")
+
+	// ErrMissingSeparator describes an error where an encoded string does
+	// not contain a separator character following a non-empty
+	// human-readable part.
+	ErrMissingSeparator = errors.New("missing separator or empty " +
+		"human-readable part")
+
+	// ErrInvalidDataValue describes an error where data to be encoded
+	// contains a value which does not fit in 5 bits.
+	ErrInvalidDataValue = errors.New("data value does not fit in 5 bits")
+
+	// ErrInvalidPadding describes an error where converting between group
+	// sizes leaves non-zero or excessive padding bits.
+	ErrInvalidPadding = errors.New("invalid padding")
+)
+
+// InvalidCharError describes an error where an encoded string contains a
+// character which is not permitted at its position.
+type InvalidCharError struct {
+	// Index is the index of the offending character.
+	Index int
+
+	// Char is the offending character.
+	Char byte
+}
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e InvalidCharError) Error() string {
+	return fmt.Sprintf("invalid character %q at index %d", e.Char, e.Index)
+}
+
+// ChecksumError describes an error where the checksum of an encoded string does
+// not match its contents.
+type ChecksumError struct {
+	// Index is the index of the character which, when corrected, makes
+	// the checksum valid.  It is -1 when no single substituted character
+	// accounts for the mismatch.
+	Index int
+
+	// Char is the character which makes the checksum valid when
+	// substituted at Index.  It is only meaningful when Index is not -1.
+	Char byte
+}
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e ChecksumError) Error() string {
+	if e.Index < 0 {
+		return "checksum mismatch"
+	}
+	return fmt.Sprintf("checksum mismatch: likely typo at index %d, "+
+		"expected %q", e.Index, e.Char)
+}
+
+// polyMod calculates the BCH checksum of the passed 5-bit values.
+func polyMod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd,
+		0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := uint(0); i < 5; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+// hrpExpand expands the human-readable part into 5-bit values for use in the
+// checksum.
+func hrpExpand(hrp string) []byte {
+	values := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]>>5)
+	}
+	values = append(values, 0)
+	for i := 0; i < len(hrp); i++ {
+		values = append(values, hrp[i]&31)
+	}
+	return values
+}
+
+// verifyChecksum returns whether or not the checksum of the passed expanded
+// human-readable part and 5-bit data, including the checksum, is valid.
+func verifyChecksum(hrpValues, data []byte) bool {
+	values := append(append([]byte(nil), hrpValues...), data...)
+	return polyMod(values) == 1
+}
+
+// createChecksum returns the checksum of the passed human-readable part and
+// 5-bit data.
+func createChecksum(hrp string, data []byte) []byte {
+	values := append(hrpExpand(hrp), data...)
+	values = append(values, make([]byte, checksumLen)...)
+	mod := polyMod(values) ^ 1
+	checksum := make([]byte, checksumLen)
+	for i := range checksum {
+		checksum[i] = byte(mod>>uint(5*(5-i))) & 31
+	}
+	return checksum
+}
+
+// validHRP returns the index of the first character of the passed
+// human-readable part which is outside of the printable US-ASCII range, or -1
+// when it is valid.
+func validHRP(hrp string) int {
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return i
+		}
+	}
+	return -1
+}
+
+// Encode encodes the passed human-readable part and data, which must consist
+// of 5-bit values, into a checksummed string.  The human-readable part is
+// converted to lowercase and the result is entirely lowercase.  The uppercase
+// form of the result, which is more compact in QR codes, is equally valid.
+func Encode(hrp string, data []byte) (string, error) {
+	if len(hrp) == 0 {
+		return "", ErrMissingSeparator
+	}
+	if len(hrp)+1+len(data)+checksumLen > MaxLength {
+		return "", ErrInvalidLength
+	}
+	if idx := validHRP(hrp); idx >= 0 {
+		return "", InvalidCharError{Index: idx, Char: hrp[idx]}
+	}
+	for _, v := range data {
+		if v >= 32 {
+			return "", ErrInvalidDataValue
+		}
+	}
+
+	hrp = strings.ToLower(hrp)
+	encoded := make([]byte, 0, len(hrp)+1+len(data)+checksumLen)
+	encoded = append(encoded, hrp...)
+	encoded = append(encoded, separator)
+	for _, v := range data {
+		encoded = append(encoded, charset[v])
+	}
+	for _, v := range createChecksum(hrp, data) {
+		encoded = append(encoded, charset[v])
+	}
+	return string(encoded), nil
+}
+
+// locateError returns the index within the data part and replacement
+// character of the single substitution which makes the checksum of the passed
+// data part valid, or -1 when there is no such substitution.  The checksum
+// detects any error affecting up to four characters, so a single substitution
+// which satisfies it is unique.
+func locateError(hrpValues, data []byte) (int, byte) {
+	values := append(append([]byte(nil), hrpValues...), data...)
+	offset := len(hrpValues)
+	for i := range data {
+		orig := values[offset+i]
+		for v := byte(0); v < 32; v++ {
+			if v == orig {
+				continue
+			}
+			values[offset+i] = v
+			if polyMod(values) == 1 {
+				return i, charset[v]
+			}
+		}
+		values[offset+i] = orig
+	}
+	return -1, 0
+}
+
+// Decode decodes the passed checksummed string and returns its lowercase
+// human-readable part along with the 5-bit data, excluding the checksum.
+//
+// Invalid characters are reported as an InvalidCharError and checksum
+// failures as a ChecksumError, both of which identify the position of the
+// error within the string.
+func Decode(s string) (string, []byte, error) {
+	if len(s) > MaxLength {
+		return "", nil, ErrInvalidLength
+	}
+	lower := strings.ToLower(s)
+	if lower != s && strings.ToUpper(s) != s {
+		return "", nil, ErrMixedCase
+	}
+
+	sep := strings.LastIndexByte(lower, separator)
+	if sep < 1 {
+		return "", nil, ErrMissingSeparator
+	}
+	if len(lower)-sep-1 < checksumLen {
+		return "", nil, ErrInvalidLength
+	}
+	hrp := lower[:sep]
+	if idx := validHRP(hrp); idx >= 0 {
+		return "", nil, InvalidCharError{Index: idx, Char: s[idx]}
+	}
+
+	data := make([]byte, 0, len(lower)-sep-1)
+	for i := sep + 1; i < len(lower); i++ {
+		v := strings.IndexByte(charset, lower[i])
+		if v < 0 {
+			return "", nil, InvalidCharError{Index: i, Char: s[i]}
+		}
+		data = append(data, byte(v))
+	}
+
+	hrpValues := hrpExpand(hrp)
+	if !verifyChecksum(hrpValues, data) {
+		idx, char := locateError(hrpValues, data)
+		if idx >= 0 {
+			idx += sep + 1
+			if lower != s {
+				char = strings.ToUpper(string(char))[0]
+			}
+		}
+		return "", nil, ChecksumError{Index: idx, Char: char}
+	}
+
+	return hrp, data[:len(data)-checksumLen], nil
+}
+
+// ConvertBits regroups the passed data from groups of fromBits bits into groups
+// of toBits bits, where both sizes are between 1 and 8.  When pad is true, any
+// remaining bits are padded with zeros to form a final group.  Otherwise
+// ErrInvalidPadding is returned when the remaining bits are non-zero or form a
+// complete input group.
+func ConvertBits(data []byte, fromBits, toBits uint8, pad bool) ([]byte, error) {
+	if fromBits < 1 || fromBits > 8 || toBits < 1 || toBits > 8 {
+		return nil, errors.New("bit group sizes must be between 1 and 8")
+	}
+
+	var acc uint32
+	var bits uint8
+	maxValue := uint32(1)<<toBits - 1
+	result := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, ErrInvalidDataValue
+		}
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte(acc>>bits&maxValue))
+		}
+	}
+
+	switch {
+	case pad && bits > 0:
+		result = append(result, byte(acc<<(toBits-bits)&maxValue))
+	case !pad && (bits >= fromBits || acc<<(toBits-bits)&maxValue != 0):
+		return nil, ErrInvalidPadding
+	}
+	return result, nil
+}
+
+// EncodeFromBase256 converts the passed 8-bit data into 5-bit groups and
+// encodes it with the passed human-readable part.
+func EncodeFromBase256(hrp string, data []byte) (string, error) {
+	converted, err := ConvertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return Encode(hrp, converted)
+}
+
+// DecodeToBase256 decodes the passed checksummed string and returns its
+// human-readable part along with the data converted back into 8-bit bytes.
+func DecodeToBase256(s string) (string, []byte, error) {
+	hrp, data, err := Decode(s)
+	if err != nil {
+		return "", nil, err
+	}
+	converted, err := ConvertBits(data, 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, converted, nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package bech32_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/abcsuite/abcutil/bech32"
+)
+
+// TestBech32 ensures the BIP0173 checksum test vectors are accepted or
+// rejected as expected and that valid strings round trip.
+func TestBech32(t *testing.T) {
+	tests := []struct {
+		str   string
+		valid bool
+	}{
+		{"A12UEL5L", true},
+		{"a12uel5l", true},
+		{"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs", true},
+		{"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", true},
+		{"11" + strings.Repeat("q", 82) + "c8247j", true},
+		{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", true},
+		{"?1ezyfcl", true},
+		{"\x201nwldj5", false},
+		{"\x7f1axkwrx", false},
+		{"\x801eym55h", false},
+		{"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx", false},
+		{"pzry9x0pq8w4e", false},
+		{"x1b4n0q5v", false},
+		{"li1dgmt3", false},
+		{"de1lg7wt\xff", false},
+		{"A1G7SGD8", false},
+		{"10a06t8", false},
+		{"1qzzfhee", false},
+		{"a12UEL5L", false},
+	}
+
+	for i, test := range tests {
+		hrp, data, err := bech32.Decode(test.str)
+		if (err == nil) != test.valid {
+			t.Errorf("Decode #%d (%q): valid %v, got error %v", i,
+				test.str, test.valid, err)
+			continue
+		}
+		if !test.valid {
+			continue
+		}
+
+		encoded, err := bech32.Encode(hrp, data)
+		if err != nil {
+			t.Errorf("Encode #%d (%q): unexpected error: %v", i,
+				test.str, err)
+			continue
+		}
+		if encoded != strings.ToLower(test.str) {
+			t.Errorf("Encode #%d: mismatched encoding -- got %s, want %s",
+				i, encoded, strings.ToLower(test.str))
+		}
+	}
+}
+
+// TestLocateTypo ensures a single mistyped character is located by the
+// checksum error.
+func TestLocateTypo(t *testing.T) {
+	data := hexToBytes("751e76e8199196d454941c45d1b3a323f1433bd6")
+	encoded, err := bech32.EncodeFromBase256("abc", data)
+	if err != nil {
+		t.Fatalf("EncodeFromBase256: unexpected error: %v", err)
+	}
+
+	for _, s := range []string{encoded, strings.ToUpper(encoded)} {
+		for _, idx := range []int{4, 10, len(s) - 1} {
+			typo := []byte(s)
+			want := typo[idx]
+			typo[idx] = 'q'
+			if want == 'q' || want == 'Q' {
+				typo[idx] = 'p'
+			}
+			if s != encoded {
+				typo[idx] = strings.ToUpper(string(typo[idx]))[0]
+			}
+
+			_, _, err := bech32.Decode(string(typo))
+			cerr, ok := err.(bech32.ChecksumError)
+			if !ok {
+				t.Errorf("Decode(%s): unexpected error %v", typo, err)
+				continue
+			}
+			if cerr.Index != idx || cerr.Char != want {
+				t.Errorf("Decode(%s): mismatched typo -- got %q at %d, "+
+					"want %q at %d", typo, cerr.Char, cerr.Index, want,
+					idx)
+			}
+		}
+	}
+
+	// Invalid characters report their position.
+	_, _, err = bech32.Decode(encoded[:8] + "b" + encoded[9:])
+	if cerr, ok := err.(bech32.InvalidCharError); !ok || cerr.Index != 8 {
+		t.Errorf("Decode: mismatched error -- got %v, want invalid "+
+			"character at index 8", err)
+	}
+}
+
+// TestConvertBits ensures data is regrouped between bit sizes and invalid
+// padding is rejected.
+func TestConvertBits(t *testing.T) {
+	tests := []struct {
+		in       string
+		fromBits uint8
+		toBits   uint8
+		pad      bool
+		out      string
+		err      error
+	}{
+		{"ff", 8, 5, true, "1f1c", nil},
+		{"1f1c", 5, 8, false, "ff", nil},
+		{"1f1d", 5, 8, false, "", bech32.ErrInvalidPadding},
+		{"1f1c00", 5, 8, false, "", bech32.ErrInvalidPadding},
+		{"20", 5, 8, false, "", bech32.ErrInvalidDataValue},
+		{"", 8, 5, true, "", nil},
+	}
+
+	for i, test := range tests {
+		got, err := bech32.ConvertBits(hexToBytes(test.in), test.fromBits,
+			test.toBits, test.pad)
+		if err != test.err {
+			t.Errorf("ConvertBits #%d: mismatched error -- got %v, want "+
+				"%v", i, err, test.err)
+			continue
+		}
+		if want := hexToBytes(test.out); err == nil && !bytes.Equal(got, want) {
+			t.Errorf("ConvertBits #%d: mismatched result -- got %x, want "+
+				"%x", i, got, want)
+		}
+	}
+}
+
+// hexToBytes converts the passed hex string into bytes and will panic if there
+// is an error.  This is only provided for the hard-coded constants so errors
+// in the source code can be detected.
+func hexToBytes(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic("invalid hex in source file: " + s)
+	}
+	return b
+}
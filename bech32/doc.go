@@ -0,0 +1,32 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+/*
+Package bech32 provides an API for working with checksummed base32 encoded
+strings in the style of BIP0173.
+
+Encoding Format
+
+An encoded string consists of a human-readable part, such as a network
+identifier, followed by the separator character "1" and a data part.  The data
+part uses a 32 character alphabet which excludes the easily confused 1, b, i,
+and o characters and ends with a 6 character checksum.  Strings are either
+entirely lowercase or entirely uppercase.  The uppercase form is useful for QR
+codes, which encode uppercase alphanumeric text more compactly.
+
+Error Detection
+
+The checksum is a BCH code which is guaranteed to detect any error affecting up
+to four characters.  When the checksum does not match, Decode returns a
+ChecksumError which identifies the position of a single mistyped character and
+the character which corrects it when such a character exists, so user
+interfaces can point out the likely typo.
+
+Converting Data
+
+The data part encodes 5-bit values.  The ConvertBits function regroups 8-bit
+bytes into 5-bit values and back, and the EncodeFromBase256 and DecodeToBase256
+functions combine the conversion with encoding and decoding.
+*/
+package bech32
@@ -0,0 +1,45 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package bech32_test
+
+import (
+	"fmt"
+
+	"github.com/abcsuite/abcutil/bech32"
+)
+
+// This example demonstrates how to encode data with a human-readable part.
+func ExampleEncodeFromBase256() {
+	encoded, err := bech32.EncodeFromBase256("abc", []byte("abc"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(encoded)
+
+	// Output:
+	// abc1v93xxm8cd5l
+}
+
+// This example demonstrates how to decode an encoded string and locate a
+// mistyped character.
+func ExampleDecodeToBase256() {
+	hrp, data, err := bech32.DecodeToBase256("abc1v93xxm8cd5l")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("%s %s\n", hrp, data)
+
+	_, _, err = bech32.DecodeToBase256("abc1v93xxm8cdol")
+	fmt.Println(err)
+	_, _, err = bech32.DecodeToBase256("abc1v93xxm8cd6l")
+	fmt.Println(err)
+
+	// Output:
+	// abc abc
+	// invalid character 'o' at index 13
+	// checksum mismatch: likely typo at index 13, expected '5'
+}
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/abcsuite/abcd/chaincfg/chainhash"
 	"github.com/abcsuite/abcd/wire"
@@ -36,26 +37,114 @@ func (e OutOfRangeError) Error() string {
 // manipulation of raw blocks.  It also memoizes hashes for the block and its
 // transactions on their first access so subsequent accesses don't have to
 // repeat the relatively expensive hashing operations.
+//
+// A Block is safe for concurrent access by multiple goroutines, including the
+// memoization of its serialized bytes and wrapped transactions, which are only
+// generated once.  The underlying wire.MsgBlock must not be modified once the
+// Block is shared.
 type Block struct {
+	mtx             sync.Mutex     // Protects the memoized fields
 	msgBlock        *wire.MsgBlock // Underlying MsgBlock
 	serializedBlock []byte         // Serialized bytes for the block
 	hash            chainhash.Hash // Cached block hash
+	header          *BlockHeader   // Wrapped block header
 	transactions    []*Tx          // Transactions
 	sTransactions   []*Tx          // Stake transactions
 	txnsGenerated   bool           // ALL wrapped transactions generated
 	sTxnsGenerated  bool           // ALL wrapped stake transactions generated
+	txLocs          []wire.TxLoc   // Locations of transactions when lazy
+	sTxLocs         []wire.TxLoc   // Locations of stake transactions when lazy
+	lazy            bool           // Transactions not yet in msgBlock
 }
 
-// MsgBlock returns the underlying wire.MsgBlock for the Block.
+// MsgBlock returns the underlying wire.MsgBlock for the Block.  For a block
+// created with NewBlockFromBytesLazy, this decodes every transaction which has
+// not already been decoded.
 func (b *Block) MsgBlock() *wire.MsgBlock {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.lazy {
+		txns := b.allTxns()
+		sTxns := b.allSTxns()
+		b.msgBlock.Transactions = make([]*wire.MsgTx, len(txns))
+		for i, tx := range txns {
+			b.msgBlock.Transactions[i] = tx.MsgTx()
+		}
+		b.msgBlock.STransactions = make([]*wire.MsgTx, len(sTxns))
+		for i, tx := range sTxns {
+			b.msgBlock.STransactions[i] = tx.MsgTx()
+		}
+		b.lazy = false
+	}
+
 	// Return the cached block.
 	return b.msgBlock
 }
 
+// numTx returns the number of transactions in the passed transaction tree of
+// the Block.  It must be called with the mutex held.
+func (b *Block) numTx(tree int8) int {
+	switch {
+	case b.lazy && tree == wire.TxTreeStake:
+		return len(b.sTxLocs)
+	case b.lazy:
+		return len(b.txLocs)
+	case tree == wire.TxTreeStake:
+		return len(b.msgBlock.STransactions)
+	}
+	return len(b.msgBlock.Transactions)
+}
+
+// newTx returns a new wrapped transaction for the transaction at the passed
+// index of the passed transaction tree.  The transaction is decoded from the
+// serialized block when the Block was created with NewBlockFromBytesLazy.  It
+// must be called with the mutex held.
+func (b *Block) newTx(tree int8, txNum int) (*Tx, error) {
+	var tx *Tx
+	switch {
+	case b.lazy:
+		loc := b.txLocs[txNum]
+		if tree == wire.TxTreeStake {
+			loc = b.sTxLocs[txNum]
+		}
+		txBytes := b.serializedBlock[loc.TxStart : loc.TxStart+loc.TxLen]
+		var err error
+		tx, err = NewTxFromBytes(txBytes)
+		if err != nil {
+			return nil, err
+		}
+	case tree == wire.TxTreeStake:
+		tx = NewTx(b.msgBlock.STransactions[txNum])
+	default:
+		tx = NewTx(b.msgBlock.Transactions[txNum])
+	}
+	tx.SetIndex(txNum)
+	tx.SetTree(tree)
+	return tx, nil
+}
+
+// mustNewTx returns a new wrapped transaction in the same way as newTx, except
+// it panics when the transaction can not be decoded.  This can not happen for
+// a block returned by NewBlockFromBytesLazy, which decodes every indexed
+// transaction before it returns, so a panic indicates a bug.
+func (b *Block) mustNewTx(tree int8, txNum int) *Tx {
+	tx, err := b.newTx(tree, txNum)
+	if err != nil {
+		str := fmt.Sprintf("unable to decode indexed transaction %d of "+
+			"tree %d: %v", txNum, tree, err)
+		panic(str)
+	}
+	return tx
+}
+
 // Bytes returns the serialized bytes for the Block.  This is equivalent to
 // calling Serialize on the underlying wire.MsgBlock, however it caches the
 // result so subsequent calls are more efficient.
 func (b *Block) Bytes() ([]byte, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
 	// Return the cached serialized bytes if it has already been generated.
 	if len(b.serializedBlock) != 0 {
 		return b.serializedBlock, nil
@@ -75,26 +164,35 @@ func (b *Block) Bytes() ([]byte, error) {
 	return serializedBlock, nil
 }
 
-// BlockHeaderBytes returns the serialized bytes for the Block's header.  This is
-// equivalent to calling Serialize on the underlying wire.MsgBlock, but it
-// returns a byte slice.
-func (b *Block) BlockHeaderBytes() ([]byte, error) {
-	// Return the cached serialized bytes if it has already been generated.
-	if len(b.serializedBlock) != 0 {
-		return b.serializedBlock, nil
+// Header returns the wrapped block header (abcutil.BlockHeader) for the Block.
+// The wrapped header shares the cached hash of the Block and, when the Block
+// has serialized bytes, the leading bytes which encode the header.
+func (b *Block) Header() *BlockHeader {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	// Return the wrapped header if it has already been generated.
+	if b.header != nil {
+		return b.header
 	}
 
-	// Serialize the MsgBlock.
-	var w bytes.Buffer
-	w.Grow(wire.MaxBlockHeaderPayload)
-	err := b.msgBlock.Header.Serialize(&w)
-	if err != nil {
-		return nil, err
+	b.header = &BlockHeader{
+		header: &b.msgBlock.Header,
+		hash:   b.hash,
+	}
+	if len(b.serializedBlock) >= wire.MaxBlockHeaderPayload {
+		n := wire.MaxBlockHeaderPayload
+		b.header.serializedHeader = b.serializedBlock[:n:n]
 	}
-	serializedBlockHeader := w.Bytes()
+	return b.header
+}
 
-	// Cache the serialized bytes and return them.
-	return serializedBlockHeader, nil
+// BlockHeaderBytes returns the serialized bytes for the Block's header.  This is
+// equivalent to calling Serialize on the header of the underlying
+// wire.MsgBlock, however it caches the result so subsequent calls are more
+// efficient.  See BlockHeader.Bytes.
+func (b *Block) BlockHeaderBytes() ([]byte, error) {
+	return b.Header().Bytes()
 }
 
 // Hash returns the block identifier hash for the Block.  This is equivalent to
@@ -120,9 +218,12 @@ func (b *Block) Hash() *chainhash.Hash {
 // underlying wire.MsgBlock, however the wrapped transaction has some helpful
 // properties such as caching the hash so subsequent calls are more efficient.
 func (b *Block) Tx(txNum int) (*Tx, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
 	// Ensure the requested transaction is in range.
-	numTx := uint64(len(b.msgBlock.Transactions))
-	if txNum < 0 || uint64(txNum) > numTx {
+	numTx := b.numTx(wire.TxTreeRegular)
+	if txNum < 0 || txNum >= numTx {
 		str := fmt.Sprintf("transaction index %d is out of range - max %d",
 			txNum, numTx-1)
 		return nil, OutOfRangeError(str)
@@ -139,9 +240,10 @@ func (b *Block) Tx(txNum int) (*Tx, error) {
 	}
 
 	// Generate and cache the wrapped transaction and return it.
-	newTx := NewTx(b.msgBlock.Transactions[txNum])
-	newTx.SetIndex(txNum)
-	newTx.SetTree(wire.TxTreeRegular)
+	newTx, err := b.newTx(wire.TxTreeRegular, txNum)
+	if err != nil {
+		return nil, err
+	}
 	b.transactions[txNum] = newTx
 	return newTx, nil
 }
@@ -149,9 +251,12 @@ func (b *Block) Tx(txNum int) (*Tx, error) {
 // STx returns a wrapped transaction (abcutil.Tx) for the stake transaction at
 // the specified index in the Block.  The supplied index is 0 based.
 func (b *Block) STx(txNum int) (*Tx, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
 	// Ensure the requested transaction is in range.
-	numTx := uint64(len(b.msgBlock.STransactions))
-	if txNum < 0 || uint64(txNum) > numTx {
+	numTx := b.numTx(wire.TxTreeStake)
+	if txNum < 0 || txNum >= numTx {
 		str := fmt.Sprintf("transaction index %d is out of range - max %d",
 			txNum, numTx-1)
 		return nil, OutOfRangeError(str)
@@ -168,9 +273,10 @@ func (b *Block) STx(txNum int) (*Tx, error) {
 	}
 
 	// Generate and cache the wrapped transaction and return it.
-	newTx := NewTx(b.msgBlock.STransactions[txNum])
-	newTx.SetIndex(txNum)
-	newTx.SetTree(wire.TxTreeStake)
+	newTx, err := b.newTx(wire.TxTreeStake, txNum)
+	if err != nil {
+		return nil, err
+	}
 	b.sTransactions[txNum] = newTx
 	return newTx, nil
 }
@@ -180,6 +286,15 @@ func (b *Block) STx(txNum int) (*Tx, error) {
 // transactions (wire.MsgTx) in the underlying wire.MsgBlock, however it
 // instead provides easy access to wrapped versions (abcutil.Tx) of them.
 func (b *Block) Transactions() []*Tx {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.allTxns()
+}
+
+// allTxns generates and returns the wrapped transactions of the Block.  It
+// must be called with the mutex held.
+func (b *Block) allTxns() []*Tx {
 	// Return transactions if they have ALL already been generated.  This
 	// flag is necessary because the wrapped transactions are lazily
 	// generated in a sparse fashion.
@@ -189,17 +304,14 @@ func (b *Block) Transactions() []*Tx {
 
 	// Generate slice to hold all of the wrapped transactions if needed.
 	if len(b.transactions) == 0 {
-		b.transactions = make([]*Tx, len(b.msgBlock.Transactions))
+		b.transactions = make([]*Tx, b.numTx(wire.TxTreeRegular))
 	}
 
 	// Generate and cache the wrapped transactions for all that haven't
 	// already been done.
 	for i, tx := range b.transactions {
 		if tx == nil {
-			newTx := NewTx(b.msgBlock.Transactions[i])
-			newTx.SetIndex(i)
-			newTx.SetTree(wire.TxTreeRegular)
-			b.transactions[i] = newTx
+			b.transactions[i] = b.mustNewTx(wire.TxTreeRegular, i)
 		}
 	}
 
@@ -212,6 +324,15 @@ func (b *Block) Transactions() []*Tx {
 // transactions (ABCwire.MsgTx) in the underlying wire.MsgBlock, however it
 // instead provides easy access to wrapped versions (util.Tx) of them.
 func (b *Block) STransactions() []*Tx {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.allSTxns()
+}
+
+// allSTxns generates and returns the wrapped stake transactions of the Block.
+// It must be called with the mutex held.
+func (b *Block) allSTxns() []*Tx {
 	// Return transactions if they have ALL already been generated.  This
 	// flag is necessary because the wrapped transactions are lazily
 	// generated in a sparse fashion.
@@ -221,17 +342,14 @@ func (b *Block) STransactions() []*Tx {
 
 	// Generate slice to hold all of the wrapped transactions if needed.
 	if len(b.sTransactions) == 0 {
-		b.sTransactions = make([]*Tx, len(b.msgBlock.STransactions))
+		b.sTransactions = make([]*Tx, b.numTx(wire.TxTreeStake))
 	}
 
 	// Generate and cache the wrapped transactions for all that haven't
 	// already been done.
 	for i, tx := range b.sTransactions {
 		if tx == nil {
-			newTx := NewTx(b.msgBlock.STransactions[i])
-			newTx.SetIndex(i)
-			newTx.SetTree(wire.TxTreeStake)
-			b.sTransactions[i] = newTx
+			b.sTransactions[i] = b.mustNewTx(wire.TxTreeStake, i)
 		}
 	}
 
@@ -279,8 +397,17 @@ func (b *Block) STxHash(txNum int) (*chainhash.Hash, error) {
 
 // TxLoc returns the offsets and lengths of each transaction in a raw block.
 // It is used to allow fast indexing into transactions within the raw byte
-// stream.
+// stream.  The locations indexed by NewBlockFromBytesLazy are returned without
+// deserializing the block again.
 func (b *Block) TxLoc() ([]wire.TxLoc, []wire.TxLoc, error) {
+	if b.txLocs != nil {
+		txLocs := make([]wire.TxLoc, len(b.txLocs))
+		copy(txLocs, b.txLocs)
+		sTxLocs := make([]wire.TxLoc, len(b.sTxLocs))
+		copy(sTxLocs, b.sTxLocs)
+		return txLocs, sTxLocs, nil
+	}
+
 	rawMsg, err := b.Bytes()
 	if err != nil {
 		return nil, nil, err
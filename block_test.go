@@ -7,6 +7,8 @@ package abcutil_test
 
 import (
 	"bytes"
+	"encoding/binary"
+	"fmt"
 	"io"
 	"reflect"
 	"testing"
@@ -202,6 +204,263 @@ func TestNewBlockFromBytes(t *testing.T) {
 	}
 }
 
+// TestNewBlockFromBytesLazy tests creation of a Block from serialized bytes
+// without decoding its transactions up front.
+func TestNewBlockFromBytesLazy(t *testing.T) {
+	// Use a copy of the test block with stake transactions so both trees
+	// are indexed.
+	msgBlock := Block100000
+	msgBlock.STransactions = Block100000.Transactions[1:3]
+	blockBytes, err := msgBlock.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	eager, err := abcutil.NewBlockFromBytes(blockBytes)
+	if err != nil {
+		t.Fatalf("NewBlockFromBytes: %v", err)
+	}
+
+	b, err := abcutil.NewBlockFromBytesLazy(blockBytes)
+	if err != nil {
+		t.Fatalf("NewBlockFromBytesLazy: %v", err)
+	}
+	if !b.Hash().IsEqual(eager.Hash()) {
+		t.Errorf("Hash: mismatched hash - got %v, want %v", b.Hash(),
+			eager.Hash())
+	}
+	if b.Height() != eager.Height() {
+		t.Errorf("Height: mismatched height - got %d, want %d",
+			b.Height(), eager.Height())
+	}
+
+	// Ensure the indexed locations match those of a fully deserialized
+	// block and share the serialized bytes.
+	txLocs, sTxLocs, err := b.TxLoc()
+	if err != nil {
+		t.Fatalf("TxLoc: %v", err)
+	}
+	wantTxLocs, wantSTxLocs, err := eager.TxLoc()
+	if err != nil {
+		t.Fatalf("TxLoc: %v", err)
+	}
+	if !reflect.DeepEqual(txLocs, wantTxLocs) ||
+		!reflect.DeepEqual(sTxLocs, wantSTxLocs) {
+
+		t.Errorf("TxLoc: mismatched locations - got %v %v, want %v %v",
+			txLocs, sTxLocs, wantTxLocs, wantSTxLocs)
+	}
+	if serialized, _ := b.Bytes(); &serialized[0] != &blockBytes[0] {
+		t.Errorf("Bytes: serialized block was copied")
+	}
+
+	// Decode individual transactions in both trees out of order.
+	for _, i := range []int{2, 0} {
+		tx, err := b.Tx(i)
+		if err != nil {
+			t.Fatalf("Tx #%d: %v", i, err)
+		}
+		wantTx, _ := eager.Tx(i)
+		if !tx.Hash().IsEqual(wantTx.Hash()) || tx.Index() != i ||
+			tx.Tree() != wire.TxTreeRegular {
+
+			t.Errorf("Tx #%d: mismatched transaction - got %v (index %d, "+
+				"tree %d), want %v", i, tx.Hash(), tx.Index(),
+				tx.Tree(), wantTx.Hash())
+		}
+	}
+	sTx, err := b.STx(1)
+	if err != nil {
+		t.Fatalf("STx: %v", err)
+	}
+	wantSTx, _ := eager.STx(1)
+	if !sTx.Hash().IsEqual(wantSTx.Hash()) || sTx.Tree() != wire.TxTreeStake {
+		t.Errorf("STx: mismatched transaction - got %v (tree %d), "+
+			"want %v", sTx.Hash(), sTx.Tree(), wantSTx.Hash())
+	}
+	if hash, err := b.STxHash(0); err != nil || !hash.IsEqual(eager.STransactions()[0].Hash()) {
+		t.Errorf("STxHash: mismatched hash - got %v (%v), want %v", hash,
+			err, eager.STransactions()[0].Hash())
+	}
+	if _, err := b.Tx(len(msgBlock.Transactions)); err == nil {
+		t.Errorf("Tx: did not get expected out of range error")
+	}
+	if _, err := b.STx(len(msgBlock.STransactions)); err == nil {
+		t.Errorf("STx: did not get expected out of range error")
+	}
+
+	// Ensure the already decoded transactions are reused when all of them
+	// are requested.
+	txns := b.Transactions()
+	if len(txns) != len(msgBlock.Transactions) {
+		t.Fatalf("Transactions: mismatched count - got %d, want %d",
+			len(txns), len(msgBlock.Transactions))
+	}
+	if tx, _ := b.Tx(2); txns[2] != tx {
+		t.Errorf("Transactions: decoded transaction was not reused")
+	}
+	if sTxns := b.STransactions(); len(sTxns) != 2 || sTxns[1] != sTx {
+		t.Errorf("STransactions: mismatched stake transactions")
+	}
+
+	// Ensure the generated MsgBlock is correct.
+	if got := b.MsgBlock(); !reflect.DeepEqual(got, &msgBlock) {
+		t.Errorf("MsgBlock: mismatched MsgBlock - got %v, want %v",
+			spew.Sdump(got), spew.Sdump(&msgBlock))
+	}
+	if tx, _ := b.Tx(0); b.MsgBlock().Transactions[0] != tx.MsgTx() {
+		t.Errorf("MsgBlock: decoded transaction was not reused")
+	}
+
+	// Ensure truncated and malformed blocks are rejected without
+	// decoding the transactions.
+	hugeCount := append([]byte(nil), blockBytes[:wire.MaxBlockHeaderPayload]...)
+	hugeCount = append(hugeCount, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0x7f)
+	badSerType := append([]byte(nil), blockBytes...)
+	badSerType[txLocs[0].TxStart+3] = 0x7f
+	tests := []struct {
+		name  string
+		bytes []byte
+	}{
+		{"truncated header", blockBytes[:100]},
+		{"missing tx count", blockBytes[:wire.MaxBlockHeaderPayload]},
+		{"truncated tx", blockBytes[:txLocs[1].TxStart+10]},
+		{"missing stake tx count", blockBytes[:sTxLocs[0].TxStart-1]},
+		{"truncated stake tx", blockBytes[:len(blockBytes)-1]},
+		{"huge tx count", hugeCount},
+		{"unsupported serialization type", badSerType},
+	}
+	for _, test := range tests {
+		if _, err := abcutil.NewBlockFromBytesLazy(test.bytes); err == nil {
+			t.Errorf("NewBlockFromBytesLazy (%s): did not get expected "+
+				"error", test.name)
+		}
+	}
+}
+
+// TestNewBlockFromBytesLazyLimits ensures blocks which the wire protocol
+// decoder rejects due to its size limits are also rejected by
+// NewBlockFromBytesLazy, so every transaction it indexes can be decoded.
+func TestNewBlockFromBytesLazyLimits(t *testing.T) {
+	// A transaction with a script one byte longer than allowed.
+	hugeScript := make([]byte, wire.MaxBlockPayload+1)
+	hugePkScript := wire.NewMsgTx()
+	hugePkScript.AddTxOut(&wire.TxOut{PkScript: hugeScript})
+	hugeSigScript := wire.NewMsgTx()
+	hugeSigScript.AddTxIn(&wire.TxIn{SignatureScript: hugeScript})
+
+	blockWith := func(tx *wire.MsgTx, stake bool) []byte {
+		msgBlock := Block100000
+		if stake {
+			msgBlock.STransactions = []*wire.MsgTx{tx}
+		} else {
+			msgBlock.Transactions = []*wire.MsgTx{tx}
+		}
+		blockBytes, err := msgBlock.Bytes()
+		if err != nil {
+			t.Fatalf("Bytes: %v", err)
+		}
+		return blockBytes
+	}
+
+	tests := []struct {
+		name  string
+		bytes []byte
+	}{
+		{"oversized pkscript", blockWith(hugePkScript, false)},
+		{"oversized stake pkscript", blockWith(hugePkScript, true)},
+		{"oversized sigscript", blockWith(hugeSigScript, false)},
+	}
+	for _, test := range tests {
+		if _, err := abcutil.NewBlockFromBytes(test.bytes); err == nil {
+			t.Errorf("NewBlockFromBytes (%s): did not get expected "+
+				"error", test.name)
+		}
+		if _, err := abcutil.NewBlockFromBytesLazy(test.bytes); err == nil {
+			t.Errorf("NewBlockFromBytesLazy (%s): did not get expected "+
+				"error", test.name)
+		}
+	}
+
+	// A block with more transactions in a tree than could fit into a
+	// block, each of which is the smallest possible transaction.
+	const minTxPayload = 15
+	numTx := wire.MaxBlockPayload/minTxPayload + 2
+	var buf bytes.Buffer
+	if err := Block100000.Header.Serialize(&buf); err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+	if err := wire.WriteVarInt(&buf, 0, uint64(numTx)); err != nil {
+		t.Fatalf("WriteVarInt: %v", err)
+	}
+	var minTx bytes.Buffer
+	if err := wire.NewMsgTx().Serialize(&minTx); err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+	if minTx.Len() != minTxPayload {
+		t.Fatalf("Serialize: unexpected minimum transaction size %d",
+			minTx.Len())
+	}
+	buf.Write(bytes.Repeat(minTx.Bytes(), numTx))
+	buf.WriteByte(0)
+	if _, err := abcutil.NewBlockFromBytesLazy(buf.Bytes()); err == nil {
+		t.Errorf("NewBlockFromBytesLazy (too many transactions): did not " +
+			"get expected error")
+	}
+}
+
+// TestNewBlockFromBytesLazyCorrupt ensures the transactions of every block
+// accepted by NewBlockFromBytesLazy can be decoded, so accessing them never
+// panics, when the serialized block is corrupted.
+func TestNewBlockFromBytesLazyCorrupt(t *testing.T) {
+	block100000Bytes, err := Block100000.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+
+	// A block with a transaction serialized without its witness.
+	var noWitness bytes.Buffer
+	if err := Block100000.Header.Serialize(&noWitness); err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+	var version [4]byte
+	binary.LittleEndian.PutUint32(version[:],
+		1|uint32(wire.TxSerializeNoWitness)<<16)
+	noWitness.WriteByte(1)
+	noWitness.Write(version[:])
+	noWitness.Write(make([]byte, 1+1+4+4))
+	noWitness.WriteByte(0)
+
+	// Blocks with each byte of their transactions inverted.
+	blocks := [][]byte{noWitness.Bytes()}
+	for i := wire.MaxBlockHeaderPayload; i < len(block100000Bytes); i++ {
+		corrupt := append([]byte(nil), block100000Bytes...)
+		corrupt[i] ^= 0xff
+		blocks = append(blocks, corrupt)
+	}
+
+	accessAll := func(b *abcutil.Block) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		b.Transactions()
+		b.STransactions()
+		b.MsgBlock()
+		return nil
+	}
+	for i, blockBytes := range blocks {
+		b, err := abcutil.NewBlockFromBytesLazy(blockBytes)
+		if err != nil {
+			continue
+		}
+		if err := accessAll(b); err != nil {
+			t.Errorf("block %d: unexpected %v", i, err)
+		}
+	}
+}
+
 // TestNewBlockFromBlockAndBytes tests creation of a Block from a MsgBlock and
 // raw bytes.
 func TestNewBlockFromBlockAndBytes(t *testing.T) {
@@ -0,0 +1,187 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/abcsuite/abcd/wire"
+	"github.com/abcsuite/abcutil"
+)
+
+// concurrencyGoroutines is the number of goroutines which access a block
+// concurrently in the concurrency tests.
+const concurrencyGoroutines = 8
+
+// TestBlockConcurrentAccess ensures blocks created by each constructor may be
+// accessed by multiple goroutines at once and that every goroutine observes
+// the same memoized transactions.  It is intended to be run with the race
+// detector enabled.
+func TestBlockConcurrentAccess(t *testing.T) {
+	msgBlock := Block100000
+	msgBlock.STransactions = Block100000.Transactions[1:3]
+	blockBytes, err := msgBlock.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	numTx := len(msgBlock.Transactions)
+	numSTx := len(msgBlock.STransactions)
+
+	newBlocks := []struct {
+		name  string
+		block func() (*abcutil.Block, error)
+	}{
+		{"NewBlock", func() (*abcutil.Block, error) {
+			return abcutil.NewBlock(&msgBlock), nil
+		}},
+		{"NewBlockFromBytes", func() (*abcutil.Block, error) {
+			return abcutil.NewBlockFromBytes(blockBytes)
+		}},
+		{"NewBlockFromBytesLazy", func() (*abcutil.Block, error) {
+			return abcutil.NewBlockFromBytesLazy(blockBytes)
+		}},
+	}
+
+	for _, test := range newBlocks {
+		b, err := test.block()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		// Each goroutine records the wrapped transactions it observed
+		// so they can be compared once all goroutines are done.
+		var wg sync.WaitGroup
+		observed := make([][]*abcutil.Tx, concurrencyGoroutines)
+		errs := make(chan error, concurrencyGoroutines)
+		for g := 0; g < concurrencyGoroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+
+				// Alternate the order of access so goroutines
+				// race to generate different transactions.
+				txns := make([]*abcutil.Tx, numTx+numSTx)
+				for j := 0; j < numTx; j++ {
+					i := j
+					if g%2 == 1 {
+						i = numTx - 1 - j
+					}
+					tx, err := b.Tx(i)
+					if err != nil {
+						errs <- err
+						return
+					}
+					txns[i] = tx
+				}
+				for i := 0; i < numSTx; i++ {
+					tx, err := b.STx(i)
+					if err != nil {
+						errs <- err
+						return
+					}
+					txns[numTx+i] = tx
+				}
+				if _, err := b.TxHash(0); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := b.STxHash(0); err != nil {
+					errs <- err
+					return
+				}
+
+				switch g % 4 {
+				case 0:
+					b.Transactions()
+				case 1:
+					b.STransactions()
+				case 2:
+					b.MsgBlock()
+				case 3:
+					if _, _, err := b.TxLoc(); err != nil {
+						errs <- err
+						return
+					}
+				}
+				serialized, err := b.Bytes()
+				if err != nil {
+					errs <- err
+					return
+				}
+				if !bytes.Equal(serialized, blockBytes) {
+					t.Errorf("%s: mismatched serialized block",
+						test.name)
+				}
+				if _, err := b.BlockHeaderBytes(); err != nil {
+					errs <- err
+					return
+				}
+				if b.Header().Height() != int64(msgBlock.Header.Height) {
+					t.Errorf("%s: mismatched header height", test.name)
+				}
+				if hash := msgBlock.BlockHash(); !b.Hash().IsEqual(&hash) {
+					t.Errorf("%s: mismatched block hash", test.name)
+				}
+				observed[g] = txns
+			}(g)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		// Ensure every goroutine observed the same memoized wrapped
+		// transactions as those returned for the whole block.
+		want := append(b.Transactions(), b.STransactions()...)
+		for g, txns := range observed {
+			for i, tx := range txns {
+				if tx != want[i] {
+					t.Errorf("%s: goroutine %d observed a different "+
+						"wrapped transaction at %d", test.name, g, i)
+				}
+			}
+		}
+		msgBlock := b.MsgBlock()
+		for i, tx := range b.Transactions() {
+			if tx.MsgTx() != msgBlock.Transactions[i] ||
+				tx.Tree() != wire.TxTreeRegular || tx.Index() != i {
+
+				t.Errorf("%s: mismatched transaction %d", test.name, i)
+			}
+		}
+	}
+}
+
+// TestTxConcurrentAccess ensures a transaction may be read by multiple
+// goroutines at once.  It is intended to be run with the race detector
+// enabled.
+func TestTxConcurrentAccess(t *testing.T) {
+	msgTx := Block100000.Transactions[1]
+	tx := abcutil.NewTx(msgTx)
+	tx.SetTree(wire.TxTreeRegular)
+	tx.SetIndex(1)
+	wantHash := msgTx.TxHash()
+
+	var wg sync.WaitGroup
+	for g := 0; g < concurrencyGoroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !tx.Hash().IsEqual(&wantHash) {
+				t.Errorf("Hash: mismatched hash - got %v, want %v",
+					tx.Hash(), wantHash)
+			}
+			if tx.MsgTx() != msgTx || tx.Tree() != wire.TxTreeRegular ||
+				tx.Index() != 1 {
+
+				t.Errorf("mismatched transaction fields")
+			}
+		}()
+	}
+	wg.Wait()
+}
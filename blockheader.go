@@ -0,0 +1,108 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/abcsuite/abcd/chaincfg/chainhash"
+	"github.com/abcsuite/abcd/wire"
+)
+
+// BlockHeader defines a block header that provides easier and more efficient
+// manipulation of raw block headers, such as those synced by SPV clients
+// without their blocks.  It caches the hash of the header when it is created
+// and memoizes its serialized bytes on their first access.
+//
+// A BlockHeader is safe for concurrent access by multiple goroutines.  The
+// underlying wire.BlockHeader must not be modified once the BlockHeader is
+// shared.
+type BlockHeader struct {
+	mtx              sync.Mutex        // Protects serializedHeader
+	header           *wire.BlockHeader // Underlying BlockHeader
+	serializedHeader []byte            // Serialized bytes for the header
+	hash             chainhash.Hash    // Cached block hash
+}
+
+// MsgBlockHeader returns the underlying wire.BlockHeader for the BlockHeader.
+func (h *BlockHeader) MsgBlockHeader() *wire.BlockHeader {
+	return h.header
+}
+
+// Bytes returns the serialized bytes for the BlockHeader.  This is equivalent
+// to calling Serialize on the underlying wire.BlockHeader, however it caches
+// the result so subsequent calls are more efficient.
+func (h *BlockHeader) Bytes() ([]byte, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	// Return the cached serialized bytes if it has already been generated.
+	if len(h.serializedHeader) != 0 {
+		return h.serializedHeader, nil
+	}
+
+	// Serialize the BlockHeader.
+	var w bytes.Buffer
+	w.Grow(wire.MaxBlockHeaderPayload)
+	err := h.header.Serialize(&w)
+	if err != nil {
+		return nil, err
+	}
+	serializedHeader := w.Bytes()
+
+	// Cache the serialized bytes and return them.
+	h.serializedHeader = serializedHeader
+	return serializedHeader, nil
+}
+
+// Hash returns the block identifier hash for the BlockHeader.  This is
+// equivalent to calling BlockHash on the underlying wire.BlockHeader, however
+// it is cached when the BlockHeader is created.
+func (h *BlockHeader) Hash() *chainhash.Hash {
+	return &h.hash
+}
+
+// Height returns the height of the block as committed to by the header.
+func (h *BlockHeader) Height() int64 {
+	return int64(h.header.Height)
+}
+
+// NewBlockHeader returns a new instance of a block header given an underlying
+// wire.BlockHeader.  See BlockHeader.
+func NewBlockHeader(header *wire.BlockHeader) *BlockHeader {
+	return &BlockHeader{
+		header: header,
+		hash:   header.BlockHash(),
+	}
+}
+
+// NewBlockHeaderFromBytes returns a new instance of a block header given the
+// serialized bytes.  Any bytes following the header, such as the transactions
+// of a serialized block, are ignored.  See BlockHeader.
+func NewBlockHeaderFromBytes(serializedHeader []byte) (*BlockHeader, error) {
+	br := bytes.NewReader(serializedHeader)
+	h, err := NewBlockHeaderFromReader(br)
+	if err != nil {
+		return nil, err
+	}
+	n := len(serializedHeader) - br.Len()
+	h.serializedHeader = serializedHeader[:n:n]
+	return h, nil
+}
+
+// NewBlockHeaderFromReader returns a new instance of a block header given a
+// Reader to deserialize the header.  See BlockHeader.
+func NewBlockHeaderFromReader(r io.Reader) (*BlockHeader, error) {
+	// Deserialize the bytes into a BlockHeader.
+	var header wire.BlockHeader
+	err := header.Deserialize(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBlockHeader(&header), nil
+}
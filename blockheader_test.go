@@ -0,0 +1,174 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/abcsuite/abcd/wire"
+	"github.com/abcsuite/abcutil"
+)
+
+// TestBlockHeader tests the API for BlockHeader.
+func TestBlockHeader(t *testing.T) {
+	header := Block100000.Header
+	wantHash := header.BlockHash()
+	wantBytes, err := header.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	blockBytes, err := Block100000.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+
+	newHeaders := []struct {
+		name   string
+		header func() (*abcutil.BlockHeader, error)
+	}{
+		{"NewBlockHeader", func() (*abcutil.BlockHeader, error) {
+			return abcutil.NewBlockHeader(&header), nil
+		}},
+		{"NewBlockHeaderFromBytes", func() (*abcutil.BlockHeader, error) {
+			return abcutil.NewBlockHeaderFromBytes(wantBytes)
+		}},
+		{"NewBlockHeaderFromBytes block", func() (*abcutil.BlockHeader, error) {
+			return abcutil.NewBlockHeaderFromBytes(blockBytes)
+		}},
+		{"NewBlockHeaderFromReader", func() (*abcutil.BlockHeader, error) {
+			return abcutil.NewBlockHeaderFromReader(
+				bytes.NewReader(wantBytes))
+		}},
+		{"Block.Header", func() (*abcutil.BlockHeader, error) {
+			return abcutil.NewBlock(&Block100000).Header(), nil
+		}},
+		{"Block.Header from bytes", func() (*abcutil.BlockHeader, error) {
+			b, err := abcutil.NewBlockFromBytes(blockBytes)
+			if err != nil {
+				return nil, err
+			}
+			return b.Header(), nil
+		}},
+	}
+
+	for _, test := range newHeaders {
+		h, err := test.header()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		// Ensure we get the same data back out.
+		if msgHeader := h.MsgBlockHeader(); !reflect.DeepEqual(msgHeader,
+			&header) {
+
+			t.Errorf("%s: MsgBlockHeader: mismatched header - got %v, "+
+				"want %v", test.name, msgHeader, header)
+		}
+		if !h.Hash().IsEqual(&wantHash) {
+			t.Errorf("%s: Hash: mismatched hash - got %v, want %v",
+				test.name, h.Hash(), wantHash)
+		}
+		if height := h.Height(); height != int64(header.Height) {
+			t.Errorf("%s: Height: mismatched height - got %v, want %v",
+				test.name, height, header.Height)
+		}
+
+		// Ensure the serialized bytes are only of the header and are
+		// cached.
+		serialized, err := h.Bytes()
+		if err != nil {
+			t.Errorf("%s: Bytes: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(serialized, wantBytes) {
+			t.Errorf("%s: Bytes: wrong bytes - got %x, want %x",
+				test.name, serialized, wantBytes)
+		}
+		cached, err := h.Bytes()
+		if err != nil {
+			t.Errorf("%s: Bytes: unexpected error: %v", test.name, err)
+			continue
+		}
+		if &cached[0] != &serialized[0] {
+			t.Errorf("%s: Bytes: serialized bytes are not cached",
+				test.name)
+		}
+	}
+}
+
+// TestBlockHeaderErrors tests the error paths for the BlockHeader API.
+func TestBlockHeaderErrors(t *testing.T) {
+	headerBytes, err := Block100000.Header.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+
+	// Truncate the serialized header so it fails to deserialize.
+	short := headerBytes[:wire.MaxBlockHeaderPayload-1]
+	_, err = abcutil.NewBlockHeaderFromBytes(short)
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("NewBlockHeaderFromBytes: did not get expected error - "+
+			"got %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	_, err = abcutil.NewBlockHeaderFromBytes(nil)
+	if err != io.EOF {
+		t.Errorf("NewBlockHeaderFromBytes: did not get expected error - "+
+			"got %v, want %v", err, io.EOF)
+	}
+}
+
+// TestBlockHeaderBytes ensures the serialized header of a block is only the
+// header regardless of whether the block has serialized bytes.
+func TestBlockHeaderBytes(t *testing.T) {
+	wantBytes, err := Block100000.Header.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	blockBytes, err := Block100000.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+
+	fromBytes, err := abcutil.NewBlockFromBytes(blockBytes)
+	if err != nil {
+		t.Fatalf("NewBlockFromBytes: %v", err)
+	}
+	lazy, err := abcutil.NewBlockFromBytesLazy(blockBytes)
+	if err != nil {
+		t.Fatalf("NewBlockFromBytesLazy: %v", err)
+	}
+	serialized := abcutil.NewBlock(&Block100000)
+	if _, err := serialized.Bytes(); err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		block *abcutil.Block
+	}{
+		{"NewBlock", abcutil.NewBlock(&Block100000)},
+		{"NewBlock serialized", serialized},
+		{"NewBlockFromBytes", fromBytes},
+		{"NewBlockFromBytesLazy", lazy},
+	}
+	for _, test := range tests {
+		got, err := test.block.BlockHeaderBytes()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !bytes.Equal(got, wantBytes) {
+			t.Errorf("%s: wrong bytes - got %x, want %x", test.name,
+				got, wantBytes)
+		}
+		if test.block.Header() != test.block.Header() {
+			t.Errorf("%s: wrapped header is not cached", test.name)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/abcsuite/abcd/wire"
+)
+
+const (
+	// txInPrefixSize is the serialized size of the prefix of a transaction
+	// input, which consists of the previous outpoint hash, index, and
+	// tree followed by the sequence number.
+	txInPrefixSize = 32 + 4 + 1 + 4
+
+	// txOutFixedSize is the serialized size of the fixed length fields of
+	// a transaction output, which are the value and script version.
+	txOutFixedSize = 8 + 2
+
+	// txInWitnessFixedSize is the serialized size of the fixed length
+	// fields of the witness of a transaction input, which are the input
+	// value, block height, and block index.
+	txInWitnessFixedSize = 8 + 4 + 4
+
+	// txLockTimeExpirySize is the serialized size of the lock time and
+	// expiry of a transaction.
+	txLockTimeExpirySize = 4 + 4
+
+	// minTxPayload is the minimum serialized size of a transaction, which
+	// is the same as that used by the wire protocol decoder.  It consists
+	// of the version, the input, output, and witness counts, and the lock
+	// time and expiry.
+	minTxPayload = 4 + 1 + 1 + 1 + txLockTimeExpirySize
+
+	// maxTxPerTree is the maximum number of transactions the wire protocol
+	// decoder accepts in each transaction tree of a block.
+	maxTxPerTree = wire.MaxBlockPayload/minTxPayload + 1
+
+	// maxScriptSize is the maximum length of a public key or signature
+	// script which the wire protocol decoder accepts.
+	maxScriptSize = wire.MaxBlockPayload
+)
+
+// txScanner walks the serialized transactions of a block to find their
+// locations without decoding them.  It follows the same grammar and enforces
+// the same script length and transaction count limits as the wire protocol
+// decoder, so blocks which the decoder rejects are rejected without decoding
+// any of their transactions.
+type txScanner struct {
+	r    *bytes.Reader
+	size int
+}
+
+// newTxScanner returns a new scanner positioned at the passed offset of the
+// passed serialized block.
+func newTxScanner(serializedBlock []byte, offset int) *txScanner {
+	s := &txScanner{
+		r:    bytes.NewReader(serializedBlock),
+		size: len(serializedBlock),
+	}
+	s.r.Seek(int64(offset), io.SeekStart)
+	return s
+}
+
+// offset returns the current position of the scanner.
+func (s *txScanner) offset() int {
+	return s.size - s.r.Len()
+}
+
+// skip advances the scanner by the passed number of bytes.
+func (s *txScanner) skip(n uint64) error {
+	if n > uint64(s.r.Len()) {
+		return io.ErrUnexpectedEOF
+	}
+	_, err := s.r.Seek(int64(n), io.SeekCurrent)
+	return err
+}
+
+// skipN advances the scanner by the passed number of fixed size elements.
+func (s *txScanner) skipN(count, size uint64) error {
+	if count > uint64(s.r.Len())/size {
+		return io.ErrUnexpectedEOF
+	}
+	return s.skip(count * size)
+}
+
+// skipVarBytes advances the scanner past a variable length byte array which
+// must not be longer than the passed maximum.  The field name is used in the
+// error which is returned when it is longer.
+func (s *txScanner) skipVarBytes(maxAllowed uint64, fieldName string) error {
+	n, err := wire.ReadVarInt(s.r, 0)
+	if err != nil {
+		return err
+	}
+	if n > maxAllowed {
+		return fmt.Errorf("%s is larger than the max allowed size "+
+			"[count %d, max %d]", fieldName, n, maxAllowed)
+	}
+	return s.skip(n)
+}
+
+// skipPrefix advances the scanner past the prefix of a transaction and
+// returns its number of inputs.
+func (s *txScanner) skipPrefix() (uint64, error) {
+	numTxIn, err := wire.ReadVarInt(s.r, 0)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.skipN(numTxIn, txInPrefixSize); err != nil {
+		return 0, err
+	}
+
+	numTxOut, err := wire.ReadVarInt(s.r, 0)
+	if err != nil {
+		return 0, err
+	}
+	for i := uint64(0); i < numTxOut; i++ {
+		if err := s.skip(txOutFixedSize); err != nil {
+			return 0, err
+		}
+		if err := s.skipVarBytes(maxScriptSize, "pkscript"); err != nil {
+			return 0, err
+		}
+	}
+
+	return numTxIn, s.skip(txLockTimeExpirySize)
+}
+
+// skipWitness advances the scanner past the witness of a transaction and
+// returns its number of inputs.
+func (s *txScanner) skipWitness() (uint64, error) {
+	numTxIn, err := wire.ReadVarInt(s.r, 0)
+	if err != nil {
+		return 0, err
+	}
+	for i := uint64(0); i < numTxIn; i++ {
+		if err := s.skip(txInWitnessFixedSize); err != nil {
+			return 0, err
+		}
+		if err := s.skipVarBytes(maxScriptSize, "sigscript"); err != nil {
+			return 0, err
+		}
+	}
+	return numTxIn, nil
+}
+
+// skipTx advances the scanner past a transaction in any of the serialization
+// types supported by the wire protocol.
+func (s *txScanner) skipTx() error {
+	var version [4]byte
+	if _, err := io.ReadFull(s.r, version[:]); err != nil {
+		return err
+	}
+	serType := wire.TxSerializeType(binary.LittleEndian.Uint32(version[:]) >> 16)
+
+	switch serType {
+	case wire.TxSerializeNoWitness:
+		_, err := s.skipPrefix()
+		return err
+
+	case wire.TxSerializeOnlyWitness:
+		_, err := s.skipWitness()
+		return err
+
+	case wire.TxSerializeFull:
+		numTxIn, err := s.skipPrefix()
+		if err != nil {
+			return err
+		}
+		numWitness, err := s.skipWitness()
+		if err != nil {
+			return err
+		}
+		if numWitness != numTxIn {
+			return fmt.Errorf("mismatched number of tx inputs (%d) and "+
+				"witnesses (%d)", numTxIn, numWitness)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported transaction serialization type %d",
+		serType)
+}
+
+// scanTree returns the locations of the transactions in the transaction tree
+// at the current position of the scanner and advances past them.
+func (s *txScanner) scanTree() ([]wire.TxLoc, error) {
+	count, err := wire.ReadVarInt(s.r, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reject counts the wire protocol decoder rejects.  Every transaction
+	// also occupies at least one byte, so a count which exceeds the
+	// remaining bytes is invalid.  This prevents a malicious count from
+	// causing a large allocation.
+	if count > maxTxPerTree {
+		return nil, fmt.Errorf("too many transactions to fit into a "+
+			"block [count %d, max %d]", count, maxTxPerTree)
+	}
+	if count > uint64(s.r.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	txLocs := make([]wire.TxLoc, count)
+	for i := range txLocs {
+		start := s.offset()
+		if err := s.skipTx(); err != nil {
+			return nil, err
+		}
+		txLocs[i] = wire.TxLoc{TxStart: start, TxLen: s.offset() - start}
+	}
+	return txLocs, nil
+}
+
+// validateTxs ensures every transaction at the passed locations of the passed
+// serialized block is decoded by the wire protocol decoder from exactly its
+// located bytes.  The decoded transactions are discarded.
+func validateTxs(serializedBlock []byte, txLocs []wire.TxLoc) error {
+	for i, loc := range txLocs {
+		var msgTx wire.MsgTx
+		txBytes := serializedBlock[loc.TxStart : loc.TxStart+loc.TxLen]
+		r := bytes.NewReader(txBytes)
+		if err := msgTx.Deserialize(r); err != nil {
+			return err
+		}
+		if r.Len() != 0 {
+			return fmt.Errorf("transaction %d has %d trailing bytes",
+				i, r.Len())
+		}
+	}
+	return nil
+}
+
+// NewBlockFromBytesLazy returns a new instance of a block given the serialized
+// bytes without retaining its decoded transactions.  The header is decoded and
+// the locations of the transactions in both the regular and stake trees are
+// indexed, and each transaction is decoded once to ensure it is valid before
+// it is discarded.  A transaction is decoded again and hashed when it is first
+// requested with Tx, STx, Transactions, STransactions, or their hash
+// functions, and every transaction is retained when MsgBlock is called.  This
+// makes scanning blocks for a subset of their transactions much faster than
+// NewBlockFromBytes, since only the requested transactions are hashed.
+//
+// The passed bytes are retained by the block without being copied, so they
+// must not be modified afterwards.
+func NewBlockFromBytesLazy(serializedBlock []byte) (*Block, error) {
+	var header wire.BlockHeader
+	err := header.Deserialize(bytes.NewReader(serializedBlock))
+	if err != nil {
+		return nil, err
+	}
+
+	s := newTxScanner(serializedBlock, wire.MaxBlockHeaderPayload)
+	txLocs, err := s.scanTree()
+	if err != nil {
+		return nil, err
+	}
+	sTxLocs, err := s.scanTree()
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode every indexed transaction so that decoding them later, when
+	// they are requested, can not fail.
+	if err := validateTxs(serializedBlock, txLocs); err != nil {
+		return nil, err
+	}
+	if err := validateTxs(serializedBlock, sTxLocs); err != nil {
+		return nil, err
+	}
+
+	return &Block{
+		msgBlock:        &wire.MsgBlock{Header: header},
+		serializedBlock: serializedBlock,
+		hash:            header.BlockHash(),
+		txLocs:          txLocs,
+		sTxLocs:         sTxLocs,
+		lazy:            true,
+	}, nil
+}
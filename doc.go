@@ -11,7 +11,26 @@ Block Overview
 A Block defines a Aero block that provides easier and more efficient
 manipulation of raw wire protocol blocks.  It also memoizes hashes for the
 block and its transactions on their first access so subsequent accesses don't
-have to repeat the relatively expensive hashing operations.
+have to repeat the relatively expensive hashing operations.  A Block may be
+shared by multiple goroutines since the memoized values are generated under a
+lock and only once.
+
+The NewBlockFromBytesLazy function creates a Block from serialized bytes by
+decoding the header and indexing the locations of the transactions in both
+trees.  Every transaction is validated when the Block is created, but is only
+retained and hashed when it is first requested, which makes scanning blocks for
+a few of their transactions much faster.
+
+The merkle roots of both transaction trees are calculated by the
+CalcMerkleRoot and CalcStakeMerkleRoot methods of a Block, and its Verify method
+ensures they match the block header.  The MerkleProof method returns a proof
+that a transaction is included in a block, which may be verified against the
+block header alone.
+
+A BlockHeader wraps a raw wire protocol block header in the same manner, caching
+its hash and serialized bytes, so code which syncs headers without their blocks,
+such as SPV clients, need not create a Block.  The Header method of a Block
+returns its wrapped header.
 
 Tx Overview
 
@@ -20,6 +39,31 @@ raw wire protocol transactions.  It memoizes the hash for the transaction on its
 first access so subsequent accesses don't have to repeat the relatively
 expensive hashing operations.
 
+The Fee and FeeRate methods of a Tx calculate the fee paid by the transaction
+given the values of the outputs spent by its inputs.  A FeeRate is expressed in
+atoms per kilobyte using only integer arithmetic, and the fee it charges for a
+transaction of a given size is rounded up to a whole atom.
+
+Amount Overview
+
+An Amount is a monetary amount counted in atoms.  Amounts are formatted and
+parsed exactly with integer arithmetic by the FormatExact, FormatLocale, and
+ParseAmount functions, where FormatLocale uses the decimal and grouping
+separators of an AmountLocale, for example to show "1.234,5 ABC".  The
+ConvertTo method returns the exact value of an amount as a rational number in
+any unit amounts may be parsed in.
+
+The names of units, such as "mABC", are provided by an AmountUnitRegistry
+which is created for the ticker of a coin.  Forks of the chain may replace the
+registry used by the package with SetAmountUnits and add names for units with
+RegisterAmountUnit, and the ParseAmountUnit function returns the unit with a
+name.
+
+An Amount is encoded as text and JSON as a number of atoms.  The AmountValue
+type selects between atoms and coins for both encodings so APIs may choose
+either, and only decodes the selected encoding.  Decoding the JSON of a bare
+Amount accepts both.
+
 Address Overview
 
 The Address interface provides an abstraction for a Aero address.  While the
@@ -43,5 +87,35 @@ To decode/encode an address:
 		return
 	}
 	fmt.Println(addr.EncodeAddress())
+
+The DecodeNetworkAddress function decodes an address without a default network
+by detecting the network from the identifier of the address.  The standard
+networks are detected automatically, while other networks must first be added
+with the RegisterNet function.
+
+Addresses may alternatively be encoded with the bech32 package, which uses a
+checksum that can locate typos, through the EncodeAddressBech32 method of each
+address type.  The encoding is prefixed by a human-readable part for the
+network, such as "abc" for the main network, and may be entirely uppercase for
+use in QR codes.  DecodeAddress and DecodeNetworkAddress accept both encodings.
+
+The AddressValue type binds an address to a network so it may be used as a
+field of structs which are encoded as text or JSON or stored in a SQL database.
+Encoding or decoding an address for a different network results in
+ErrWrongAddressNetwork.
+
+Payment requests of the form "abc:<address>?amount=<coins>&label=<label>" are
+parsed with the ParseURI function and created with the String method of the
+URI type.
+
+Script Overview
+
+The PayToAddrScript function returns the standard public key script which pays
+to an address, and the ExtractAddresses function performs the reverse by
+returning the class of a public key script along with the addresses it pays to.
+Multisig redeem scripts, and the pay-to-script-hash addresses which pay to
+them, are created with the NewAddressMultiSig function and decoded with the
+DecodeMultiSigScript function.  Since OP_CHECKMULTISIG only verifies secp256k1
+signatures, Ed25519 public keys can not be used in multisig redeem scripts.
 */
 package abcutil
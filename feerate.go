@@ -0,0 +1,158 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+const (
+	// feeRateBytes is the number of bytes a FeeRate is expressed per.
+	feeRateBytes = 1000
+
+	// feeRateSuffix is the suffix of the string representation of a fee
+	// rate.
+	feeRateSuffix = "/kB"
+)
+
+var (
+	// ErrInvalidFeeRate describes an error where a fee rate string is not
+	// of the form "<amount> <unit>/kB".
+	ErrInvalidFeeRate = errors.New("invalid fee rate")
+
+	// ErrInvalidTxSize describes an error where a fee or fee rate is
+	// calculated for a transaction size which is not positive.
+	ErrInvalidTxSize = errors.New("transaction size must be positive")
+
+	// ErrInputValueCount describes an error where the number of input
+	// values passed to calculate the fee of a transaction does not match
+	// its number of inputs.
+	ErrInputValueCount = errors.New("number of input values does not " +
+		"match the number of transaction inputs")
+)
+
+// FeeRate is a transaction fee rate expressed in atoms per kilobyte (1000
+// bytes) of serialized transaction.  All calculations on fee rates use integer
+// arithmetic.
+type FeeRate int64
+
+// NewFeeRate returns the fee rate of a transaction with the passed serialized
+// size which pays the passed fee.  The rate is rounded down to a whole atom
+// per kilobyte so a fee calculated from it never exceeds the passed fee.  The
+// fee must be valid as determined by Amount.IsValid.
+func NewFeeRate(fee Amount, size int) (FeeRate, error) {
+	if err := fee.check(); err != nil {
+		return 0, err
+	}
+	if size <= 0 {
+		return 0, ErrInvalidTxSize
+	}
+	return FeeRate(int64(fee) * feeRateBytes / int64(size)), nil
+}
+
+// FeeRatePerKB returns the fee rate which charges the passed amount per
+// kilobyte.
+func FeeRatePerKB(perKB Amount) FeeRate {
+	return FeeRate(perKB)
+}
+
+// PerKB returns the amount charged per kilobyte at the fee rate.
+func (r FeeRate) PerKB() Amount {
+	return Amount(r)
+}
+
+// Fee returns the fee for a transaction with the passed serialized size at the
+// fee rate.  The fee is rounded up to a whole atom so it always meets the rate.
+// The fee rate must not be negative, and ErrAmountExceedsMax is returned when
+// the fee exceeds MaxAmount.
+func (r FeeRate) Fee(size int) (Amount, error) {
+	if r < 0 {
+		return 0, ErrNegativeAmount
+	}
+	if size < 0 {
+		return 0, ErrInvalidTxSize
+	}
+
+	// Calculate ceil(r * size / 1000) in two parts so the intermediate
+	// products can't overflow for any fee which does not exceed the
+	// maximum.
+	whole, rem := int64(r)/feeRateBytes, int64(r)%feeRateBytes
+	if whole != 0 && int64(size) > int64(MaxAmount)/whole {
+		return 0, ErrAmountExceedsMax
+	}
+	if rem != 0 && int64(size) > math.MaxInt64/feeRateBytes {
+		return 0, ErrAmountExceedsMax
+	}
+	fee := Amount(whole * int64(size))
+	fracAtoms := rem * int64(size)
+	fee += Amount((fracAtoms + feeRateBytes - 1) / feeRateBytes)
+	if err := fee.check(); err != nil {
+		return 0, err
+	}
+	return fee, nil
+}
+
+// Cmp compares the fee rate to the passed fee rate and returns -1, 0, or 1
+// when it is less than, equal to, or greater than the passed fee rate,
+// respectively.
+func (r FeeRate) Cmp(other FeeRate) int {
+	switch {
+	case r < other:
+		return -1
+	case r > other:
+		return 1
+	}
+	return 0
+}
+
+// Less returns whether or not the fee rate is less than the passed fee rate.
+func (r FeeRate) Less(other FeeRate) bool {
+	return r < other
+}
+
+// MaxFeeRate returns the greatest of the passed fee rates, or zero when none
+// are passed.
+func MaxFeeRate(rates ...FeeRate) FeeRate {
+	var max FeeRate
+	for i, rate := range rates {
+		if i == 0 || rate > max {
+			max = rate
+		}
+	}
+	return max
+}
+
+// String returns the fee rate in the form "X ABC/kB" where X is the exact
+// number of coins per kilobyte.
+func (r FeeRate) String() string {
+	return Amount(r).Format(AmountCoin) + feeRateSuffix
+}
+
+// ParseFeeRate parses a fee rate of the form "X <unit>/kB", such as
+// "0.0001 ABC/kB" or "10000 Atom/kB", as returned by String.  The amount is
+// parsed exactly with ParseAmount and must not be negative.
+func ParseFeeRate(s string) (FeeRate, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasSuffix(s, feeRateSuffix) {
+		return 0, ErrInvalidFeeRate
+	}
+	s = strings.TrimSuffix(s, feeRateSuffix)
+
+	// The unit is required so the rate is not ambiguous.
+	end := strings.LastIndexAny(s, "0123456789.")
+	if end < 0 || strings.TrimSpace(s[end+1:]) == "" {
+		return 0, ErrInvalidFeeRate
+	}
+	perKB, err := ParseAmount(s, AmountCoin)
+	if err != nil {
+		return 0, err
+	}
+	if perKB < 0 {
+		return 0, ErrNegativeAmount
+	}
+	return FeeRate(perKB), nil
+}
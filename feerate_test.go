@@ -0,0 +1,164 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"testing"
+
+	. "github.com/abcsuite/abcutil"
+)
+
+// TestNewFeeRate ensures fee rates are calculated from a fee and size with the
+// result rounded down.
+func TestNewFeeRate(t *testing.T) {
+	tests := []struct {
+		name string
+		fee  Amount
+		size int
+		rate FeeRate
+		err  error
+	}{
+		{"exact", 2500, 250, 10000, nil},
+		{"round down", 1000, 3, 333333, nil},
+		{"below one atom per kB", 1, 1001, 0, nil},
+		{"zero fee", 0, 250, 0, nil},
+		{"max fee", MaxAmount, 1, FeeRate(MaxAmount) * 1000, nil},
+		{"zero size", 1000, 0, 0, ErrInvalidTxSize},
+		{"negative fee", -1, 250, 0, ErrNegativeAmount},
+		{"fee exceeds max", MaxAmount + 1, 250, 0, ErrAmountExceedsMax},
+	}
+
+	for _, test := range tests {
+		rate, err := NewFeeRate(test.fee, test.size)
+		if err != test.err {
+			t.Errorf("%v: mismatched error -- got %v, want %v",
+				test.name, err, test.err)
+			continue
+		}
+		if rate != test.rate {
+			t.Errorf("%v: mismatched rate -- got %d, want %d",
+				test.name, rate, test.rate)
+		}
+	}
+}
+
+// TestFeeRateFee ensures fees are calculated from a fee rate with the result
+// rounded up and that the calculation does not overflow.
+func TestFeeRateFee(t *testing.T) {
+	tests := []struct {
+		name string
+		rate FeeRate
+		size int
+		fee  Amount
+		err  error
+	}{
+		{"exact", 10000, 250, 2500, nil},
+		{"round up", 1, 1, 1, nil},
+		{"round up remainder", 1001, 999, 1000, nil},
+		{"round trip", 333333, 3, 1000, nil},
+		{"zero size", 10000, 0, 0, nil},
+		{"zero rate", 0, 250, 0, nil},
+		{"max", FeeRatePerKB(MaxAmount), 1000, MaxAmount, nil},
+		{"exceeds max", FeeRatePerKB(MaxAmount), 1001, 0, ErrAmountExceedsMax},
+		{"huge size", 1, 1 << 62, 0, ErrAmountExceedsMax},
+		{"negative rate", -1, 250, 0, ErrNegativeAmount},
+		{"negative size", 10000, -1, 0, ErrInvalidTxSize},
+	}
+
+	for _, test := range tests {
+		fee, err := test.rate.Fee(test.size)
+		if err != test.err {
+			t.Errorf("%v: mismatched error -- got %v, want %v",
+				test.name, err, test.err)
+			continue
+		}
+		if fee != test.fee {
+			t.Errorf("%v: mismatched fee -- got %d, want %d",
+				test.name, fee, test.fee)
+		}
+	}
+}
+
+// TestFeeRateCompare ensures the comparison helpers order fee rates.
+func TestFeeRateCompare(t *testing.T) {
+	low, high := FeeRatePerKB(1000), FeeRatePerKB(10000)
+	if low.Cmp(high) != -1 || high.Cmp(low) != 1 || low.Cmp(low) != 0 {
+		t.Errorf("Cmp: mismatched ordering of %v and %v", low, high)
+	}
+	if !low.Less(high) || high.Less(low) || low.Less(low) {
+		t.Errorf("Less: mismatched ordering of %v and %v", low, high)
+	}
+	if max := MaxFeeRate(low, high, low); max != high {
+		t.Errorf("MaxFeeRate: mismatched rate -- got %v, want %v", max,
+			high)
+	}
+	if max := MaxFeeRate(); max != 0 {
+		t.Errorf("MaxFeeRate: mismatched rate of no rates -- got %v", max)
+	}
+	if perKB := high.PerKB(); perKB != 10000 {
+		t.Errorf("PerKB: mismatched amount -- got %d, want 10000", perKB)
+	}
+}
+
+// TestFeeRateString ensures fee rates round trip through their string form and
+// that malformed fee rates are rejected.
+func TestFeeRateString(t *testing.T) {
+	tests := []struct {
+		name string
+		rate FeeRate
+		s    string
+	}{
+		{"zero", 0, "0 ABC/kB"},
+		{"one atom", 1, "0.00000001 ABC/kB"},
+		{"default relay", 10000, "0.0001 ABC/kB"},
+		{"whole coins", 2e8, "2 ABC/kB"},
+	}
+
+	for _, test := range tests {
+		if s := test.rate.String(); s != test.s {
+			t.Errorf("%v: mismatched string -- got %q, want %q",
+				test.name, s, test.s)
+		}
+		rate, err := ParseFeeRate(test.s)
+		if err != nil {
+			t.Errorf("%v: unexpected parse error: %v", test.name, err)
+			continue
+		}
+		if rate != test.rate {
+			t.Errorf("%v: mismatched parsed rate -- got %d, want %d",
+				test.name, rate, test.rate)
+		}
+	}
+
+	parseTests := []struct {
+		name string
+		s    string
+		rate FeeRate
+		err  error
+	}{
+		{"atoms", "10000 Atom/kB", 10000, nil},
+		{"surrounding space", " 0.0001 ABC/kB ", 10000, nil},
+		{"missing suffix", "0.0001 ABC", 0, ErrInvalidFeeRate},
+		{"per byte", "0.0001 ABC/B", 0, ErrInvalidFeeRate},
+		{"missing unit", "0.0001/kB", 0, ErrInvalidFeeRate},
+		{"empty", "/kB", 0, ErrInvalidFeeRate},
+		{"negative", "-0.0001 ABC/kB", 0, ErrNegativeAmount},
+		{"too precise", "0.000000001 ABC/kB", 0, ErrInvalidAmount},
+		{"unknown unit", "1 XYZ/kB", 0, ErrUnknownAmountUnit},
+	}
+
+	for _, test := range parseTests {
+		rate, err := ParseFeeRate(test.s)
+		if err != test.err {
+			t.Errorf("%v: mismatched error -- got %v, want %v",
+				test.name, err, test.err)
+			continue
+		}
+		if rate != test.rate {
+			t.Errorf("%v: mismatched rate -- got %d, want %d",
+				test.name, rate, test.rate)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package fiat
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+)
+
+// maxDecimals is the maximum number of decimal places of the minor unit of a
+// currency.  It is large enough for any real currency while ensuring the
+// scale of the minor unit fits in an int64.
+const maxDecimals = 18
+
+var (
+	// ErrInvalidCurrency describes an error where a currency has an empty
+	// code or a number of decimal places which is negative or greater
+	// than 18.
+	ErrInvalidCurrency = errors.New("invalid currency")
+
+	// ErrUnknownRoundingMode describes an error where a rounding mode is
+	// not one of the defined RoundingMode values.
+	ErrUnknownRoundingMode = errors.New("unknown rounding mode")
+)
+
+// Currency describes a fiat currency by its code and the number of decimal
+// places of its minor unit, for example two for the cent of the US dollar.
+type Currency struct {
+	// Code is the code of the currency, typically the ISO 4217 code.
+	Code string
+
+	// Decimals is the number of decimal places of the minor unit.
+	Decimals int
+}
+
+// These variables define common currencies.
+var (
+	USD = Currency{Code: "USD", Decimals: 2}
+	EUR = Currency{Code: "EUR", Decimals: 2}
+	GBP = Currency{Code: "GBP", Decimals: 2}
+	JPY = Currency{Code: "JPY", Decimals: 0}
+)
+
+// validate returns ErrInvalidCurrency when the currency is not valid.
+func (c Currency) validate() error {
+	if c.Code == "" || c.Decimals < 0 || c.Decimals > maxDecimals {
+		return ErrInvalidCurrency
+	}
+	return nil
+}
+
+// minorPerMajor returns the number of minor units in a major unit of the
+// currency.
+func (c Currency) minorPerMajor() *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(c.Decimals)), nil)
+}
+
+// String returns the code of the currency.
+func (c Currency) String() string {
+	return c.Code
+}
+
+// RoundingMode selects how a converted value which falls between two whole
+// units is rounded.
+type RoundingMode uint8
+
+const (
+	// RoundHalfEven rounds to the nearest whole unit, and to the even
+	// unit when the value is exactly halfway between two units.  This is
+	// also known as banker's rounding and does not bias totals.
+	RoundHalfEven RoundingMode = iota
+
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+
+	// RoundCeil rounds towards positive infinity.
+	RoundCeil
+)
+
+// String returns the rounding mode as a human-readable string.
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfEven:
+		return "half-even"
+	case RoundFloor:
+		return "floor"
+	case RoundCeil:
+		return "ceil"
+	}
+	return "unknown (" + strconv.FormatUint(uint64(m), 10) + ")"
+}
+
+// round returns the passed rational number rounded to an integer with the
+// rounding mode.
+func (m RoundingMode) round(x *big.Rat) (*big.Int, error) {
+	if m > RoundCeil {
+		return nil, ErrUnknownRoundingMode
+	}
+
+	// The denominator of a big.Rat is always positive, so the Euclidean
+	// quotient is the floor of the value and the remainder is not
+	// negative.
+	q, rem := new(big.Int).DivMod(x.Num(), x.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		return q, nil
+	}
+
+	switch m {
+	case RoundFloor:
+		return q, nil
+	case RoundCeil:
+		return q.Add(q, big.NewInt(1)), nil
+	case RoundHalfEven:
+		switch rem.Lsh(rem, 1).Cmp(x.Denom()) {
+		case 1:
+			return q.Add(q, big.NewInt(1)), nil
+		case 0:
+			if q.Bit(0) == 1 {
+				return q.Add(q, big.NewInt(1)), nil
+			}
+		}
+	}
+	return q, nil
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+/*
+Package fiat provides exact conversion of coin amounts to and from fiat
+currencies.
+
+Exchange Rates
+
+A Rate is the price of one coin in the major unit of a Currency, such as 12.34
+US dollars, and is held as an exact rational number so no precision is lost
+to floating point.  Rates are created from a big.Rat with NewRate or parsed
+from a decimal or fractional string with ParseRate.  The Cross function
+returns the exact exchange rate between the currencies of two rates.
+
+Conversion and Rounding
+
+The ToFiat and FromFiat functions convert an abcutil.Amount to and from an
+integer number of minor units of the currency, such as cents.  The result of a
+conversion is rounded with the passed RoundingMode, which is one of
+RoundHalfEven, RoundFloor, or RoundCeil.  Half-even rounding does not bias
+totals and suits displaying values, while floor and ceiling rounding allow
+callers to round in their own favor or the favor of their customers.
+
+Rate Sources
+
+The Source interface abstracts providers of exchange rates such as price feeds.
+The MemorySource type implements Source with rates held in memory, which is
+useful for tests.
+*/
+package fiat
@@ -0,0 +1,45 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package fiat_test
+
+import (
+	"fmt"
+
+	"github.com/abcsuite/abcutil"
+	"github.com/abcsuite/abcutil/fiat"
+)
+
+// This example demonstrates how to value an amount in a fiat currency using a
+// rate source.
+func ExampleRate_ToFiat() {
+	rate, err := fiat.ParseRate(fiat.USD, "12.34")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	source := fiat.NewMemorySource(rate)
+
+	rate, err = source.Rate("USD")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	amount := abcutil.Amount(123456789)
+	for _, mode := range []fiat.RoundingMode{fiat.RoundHalfEven,
+		fiat.RoundFloor, fiat.RoundCeil} {
+
+		cents, err := rate.ToFiat(amount, mode)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("%v is worth %d cents (%v)\n", amount, cents, mode)
+	}
+
+	// Output:
+	// 1.23456789 ABC is worth 1523 cents (half-even)
+	// 1.23456789 ABC is worth 1523 cents (floor)
+	// 1.23456789 ABC is worth 1524 cents (ceil)
+}
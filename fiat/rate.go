@@ -0,0 +1,131 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package fiat
+
+import (
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/abcsuite/abcutil"
+)
+
+var (
+	// ErrInvalidRate describes an error where an exchange rate is not a
+	// positive number.
+	ErrInvalidRate = errors.New("exchange rate must be a positive number")
+
+	// ErrConversionOverflow describes an error where the result of a
+	// conversion does not fit in an int64.
+	ErrConversionOverflow = errors.New("converted value overflows int64")
+)
+
+var (
+	// atomsPerCoin is the number of atoms in a coin as a big.Int.
+	atomsPerCoin = big.NewInt(abcutil.AtomsPerCoin)
+
+	// minInt64 and maxInt64 bound the results of conversions.
+	minInt64 = big.NewInt(math.MinInt64)
+	maxInt64 = big.NewInt(math.MaxInt64)
+)
+
+// Rate is an exchange rate between coins and a fiat currency, expressed as
+// the exact price of one coin in the major unit of the currency, for example
+// 12.34 US dollars.  A Rate is immutable and safe for concurrent access.
+type Rate struct {
+	currency Currency
+	price    *big.Rat
+}
+
+// NewRate returns a new exchange rate for the passed currency where one coin
+// is worth the passed price in the major unit of the currency.  The price is
+// copied and must be positive.
+func NewRate(currency Currency, price *big.Rat) (*Rate, error) {
+	if err := currency.validate(); err != nil {
+		return nil, err
+	}
+	if price == nil || price.Sign() <= 0 {
+		return nil, ErrInvalidRate
+	}
+	return &Rate{currency: currency, price: new(big.Rat).Set(price)}, nil
+}
+
+// ParseRate returns a new exchange rate for the passed currency where one coin
+// is worth the passed price in the major unit of the currency.  The price is
+// parsed exactly and may be a decimal, such as "12.34", or a fraction, such as
+// "617/50".
+func ParseRate(currency Currency, price string) (*Rate, error) {
+	p, ok := new(big.Rat).SetString(price)
+	if !ok {
+		return nil, ErrInvalidRate
+	}
+	return NewRate(currency, p)
+}
+
+// Currency returns the fiat currency of the exchange rate.
+func (r *Rate) Currency() Currency {
+	return r.currency
+}
+
+// Price returns a copy of the price of one coin in the major unit of the
+// currency.
+func (r *Rate) Price() *big.Rat {
+	return new(big.Rat).Set(r.price)
+}
+
+// String returns the price of one coin as an exact fraction followed by the
+// currency code, such as "617/50 USD".
+func (r *Rate) String() string {
+	return r.price.RatString() + " " + r.currency.Code
+}
+
+// toInt64 returns the passed rational number rounded with the passed rounding
+// mode as an int64.
+func toInt64(x *big.Rat, mode RoundingMode) (int64, error) {
+	n, err := mode.round(x)
+	if err != nil {
+		return 0, err
+	}
+	if n.Cmp(minInt64) < 0 || n.Cmp(maxInt64) > 0 {
+		return 0, ErrConversionOverflow
+	}
+	return n.Int64(), nil
+}
+
+// ToFiat returns the value of the passed amount in minor units of the
+// currency, such as cents, rounded with the passed rounding mode.
+func (r *Rate) ToFiat(amount abcutil.Amount, mode RoundingMode) (int64, error) {
+	// minor = atoms * price * minorPerMajor / atomsPerCoin
+	value := new(big.Rat).SetInt64(int64(amount))
+	value.Mul(value, r.price)
+	value.Mul(value, new(big.Rat).SetFrac(r.currency.minorPerMajor(),
+		atomsPerCoin))
+	return toInt64(value, mode)
+}
+
+// FromFiat returns the amount worth the passed value in minor units of the
+// currency, such as cents, rounded to a whole atom with the passed rounding
+// mode.
+func (r *Rate) FromFiat(minor int64, mode RoundingMode) (abcutil.Amount, error) {
+	// atoms = minor * atomsPerCoin / (price * minorPerMajor)
+	value := new(big.Rat).SetInt64(minor)
+	value.Quo(value, r.price)
+	value.Mul(value, new(big.Rat).SetFrac(atomsPerCoin,
+		r.currency.minorPerMajor()))
+	atoms, err := toInt64(value, mode)
+	if err != nil {
+		return 0, err
+	}
+	return abcutil.Amount(atoms), nil
+}
+
+// Cross returns the exchange rate between the currencies of the passed rate
+// and this rate, expressed as the exact price of one major unit of the
+// currency of the passed rate in the major unit of the currency of this
+// rate.  For example, with rates of 12 USD and 10 EUR per coin, the cross rate
+// of usd.Cross(eur) is 6/5 US dollars per euro.
+func (r *Rate) Cross(other *Rate) *big.Rat {
+	return new(big.Rat).Quo(r.price, other.price)
+}
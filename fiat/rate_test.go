@@ -0,0 +1,205 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package fiat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/abcsuite/abcutil"
+	"github.com/abcsuite/abcutil/fiat"
+)
+
+// mustParseRate returns the exchange rate parsed by fiat.ParseRate and fails
+// the test when it can not be parsed.
+func mustParseRate(t *testing.T, currency fiat.Currency, price string) *fiat.Rate {
+	rate, err := fiat.ParseRate(currency, price)
+	if err != nil {
+		t.Fatalf("ParseRate(%v, %q): unexpected error: %v", currency,
+			price, err)
+	}
+	return rate
+}
+
+// TestNewRate ensures exchange rates are created exactly and that invalid
+// rates and currencies are rejected.
+func TestNewRate(t *testing.T) {
+	rate := mustParseRate(t, fiat.USD, "12.34")
+	if price := rate.Price(); price.Cmp(big.NewRat(617, 50)) != 0 {
+		t.Errorf("Price: mismatched price -- got %v, want 617/50", price)
+	}
+	if currency := rate.Currency(); currency != fiat.USD {
+		t.Errorf("Currency: mismatched currency -- got %v, want %v",
+			currency, fiat.USD)
+	}
+	if s := rate.String(); s != "617/50 USD" {
+		t.Errorf("String: mismatched string -- got %s, want 617/50 USD", s)
+	}
+
+	// Modifying the returned price or the price the rate was created from
+	// must not modify the rate.
+	rate.Price().SetInt64(1)
+	price := big.NewRat(3, 2)
+	rate, err := fiat.NewRate(fiat.EUR, price)
+	if err != nil {
+		t.Fatalf("NewRate: unexpected error: %v", err)
+	}
+	price.SetInt64(1)
+	if p := rate.Price(); p.Cmp(big.NewRat(3, 2)) != 0 {
+		t.Errorf("Price: rate modified through its price -- got %v", p)
+	}
+
+	tests := []struct {
+		name     string
+		currency fiat.Currency
+		price    string
+		err      error
+	}{
+		{"fraction", fiat.USD, "617/50", nil},
+		{"many decimals", fiat.JPY, "1500.123456789", nil},
+		{"zero", fiat.USD, "0", fiat.ErrInvalidRate},
+		{"negative", fiat.USD, "-12.34", fiat.ErrInvalidRate},
+		{"malformed", fiat.USD, "12,34", fiat.ErrInvalidRate},
+		{"empty code", fiat.Currency{Decimals: 2}, "1", fiat.ErrInvalidCurrency},
+		{"negative decimals", fiat.Currency{Code: "XXX", Decimals: -1}, "1",
+			fiat.ErrInvalidCurrency},
+		{"too many decimals", fiat.Currency{Code: "XXX", Decimals: 19}, "1",
+			fiat.ErrInvalidCurrency},
+	}
+	for _, test := range tests {
+		_, err := fiat.ParseRate(test.currency, test.price)
+		if err != test.err {
+			t.Errorf("ParseRate (%s): mismatched error -- got %v, want %v",
+				test.name, err, test.err)
+		}
+	}
+	if _, err := fiat.NewRate(fiat.USD, nil); err != fiat.ErrInvalidRate {
+		t.Errorf("NewRate: mismatched error -- got %v, want %v", err,
+			fiat.ErrInvalidRate)
+	}
+}
+
+// TestRateToFiat ensures amounts are converted to minor units of a currency
+// with each rounding mode.
+func TestRateToFiat(t *testing.T) {
+	usd := mustParseRate(t, fiat.USD, "12.34")
+	par := mustParseRate(t, fiat.USD, "1")
+	jpy := mustParseRate(t, fiat.JPY, "1500")
+	huge := mustParseRate(t, fiat.USD, "1e20")
+
+	tests := []struct {
+		name     string
+		rate     *fiat.Rate
+		amount   abcutil.Amount
+		halfEven int64
+		floor    int64
+		ceil     int64
+		err      error
+	}{
+		{"exact", usd, 150000000, 1851, 1851, 1851, nil},
+		{"one atom", usd, 1, 0, 0, 1, nil},
+		{"negative atom", usd, -1, 0, -1, 0, nil},
+		{"round up", usd, 123457, 2, 1, 2, nil},
+		{"half to even down", par, 500000, 0, 0, 1, nil},
+		{"half to even up", par, 1500000, 2, 1, 2, nil},
+		{"half to even down again", par, 2500000, 2, 2, 3, nil},
+		{"negative half to even", par, -2500000, -2, -3, -2, nil},
+		{"negative half to even up", par, -1500000, -2, -2, -1, nil},
+		{"no minor unit", jpy, 150000000, 2250, 2250, 2250, nil},
+		{"max amount", usd, abcutil.MaxAmount, 25914000000, 25914000000,
+			25914000000, nil},
+		{"overflow", huge, abcutil.MaxAmount, 0, 0, 0,
+			fiat.ErrConversionOverflow},
+	}
+
+	for _, test := range tests {
+		modes := []struct {
+			mode fiat.RoundingMode
+			want int64
+		}{
+			{fiat.RoundHalfEven, test.halfEven},
+			{fiat.RoundFloor, test.floor},
+			{fiat.RoundCeil, test.ceil},
+		}
+		for _, m := range modes {
+			got, err := test.rate.ToFiat(test.amount, m.mode)
+			if err != test.err {
+				t.Errorf("ToFiat (%s, %v): mismatched error -- got %v, "+
+					"want %v", test.name, m.mode, err, test.err)
+				continue
+			}
+			if got != m.want {
+				t.Errorf("ToFiat (%s, %v): mismatched value -- got %d, "+
+					"want %d", test.name, m.mode, got, m.want)
+			}
+		}
+	}
+
+	_, err := usd.ToFiat(150000000, fiat.RoundingMode(3))
+	if err != fiat.ErrUnknownRoundingMode {
+		t.Errorf("ToFiat: mismatched error -- got %v, want %v", err,
+			fiat.ErrUnknownRoundingMode)
+	}
+}
+
+// TestRateFromFiat ensures minor units of a currency are converted to amounts
+// with each rounding mode.
+func TestRateFromFiat(t *testing.T) {
+	usd := mustParseRate(t, fiat.USD, "12.34")
+	jpy := mustParseRate(t, fiat.JPY, "1500")
+	tiny := mustParseRate(t, fiat.USD, "1/1000000000000")
+
+	tests := []struct {
+		name     string
+		rate     *fiat.Rate
+		minor    int64
+		halfEven abcutil.Amount
+		floor    abcutil.Amount
+		ceil     abcutil.Amount
+		err      error
+	}{
+		{"exact", usd, 1851, 150000000, 150000000, 150000000, nil},
+		{"one cent", usd, 1, 81037, 81037, 81038, nil},
+		{"negative cent", usd, -1, -81037, -81038, -81037, nil},
+		{"one yen", jpy, 1, 66667, 66666, 66667, nil},
+		{"zero", usd, 0, 0, 0, 0, nil},
+		{"overflow", tiny, 1 << 40, 0, 0, 0, fiat.ErrConversionOverflow},
+	}
+
+	for _, test := range tests {
+		modes := []struct {
+			mode fiat.RoundingMode
+			want abcutil.Amount
+		}{
+			{fiat.RoundHalfEven, test.halfEven},
+			{fiat.RoundFloor, test.floor},
+			{fiat.RoundCeil, test.ceil},
+		}
+		for _, m := range modes {
+			got, err := test.rate.FromFiat(test.minor, m.mode)
+			if err != test.err {
+				t.Errorf("FromFiat (%s, %v): mismatched error -- got %v, "+
+					"want %v", test.name, m.mode, err, test.err)
+				continue
+			}
+			if got != m.want {
+				t.Errorf("FromFiat (%s, %v): mismatched amount -- got %d, "+
+					"want %d", test.name, m.mode, got, m.want)
+			}
+		}
+	}
+}
+
+// TestRateCross ensures the cross rate between two currencies is exact.
+func TestRateCross(t *testing.T) {
+	usd := mustParseRate(t, fiat.USD, "12")
+	eur := mustParseRate(t, fiat.EUR, "10")
+	if cross := usd.Cross(eur); cross.Cmp(big.NewRat(6, 5)) != 0 {
+		t.Errorf("Cross: mismatched rate -- got %v, want 6/5", cross)
+	}
+	if cross := eur.Cross(usd); cross.Cmp(big.NewRat(5, 6)) != 0 {
+		t.Errorf("Cross: mismatched rate -- got %v, want 5/6", cross)
+	}
+}
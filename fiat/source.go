@@ -0,0 +1,72 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package fiat
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrRateNotFound describes an error where a rate source has no exchange rate
+// for a currency.
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// Source is the interface which must be implemented by providers of exchange
+// rates, for example a price feed or a database of historical rates.
+type Source interface {
+	// Rate returns the current exchange rate for the currency with the
+	// passed code.  ErrRateNotFound should be returned when the source
+	// has no rate for the currency.
+	Rate(code string) (*Rate, error)
+}
+
+// MemorySource is a Source which returns exchange rates held in memory.  It is
+// primarily useful for tests and for applications which update rates from an
+// external feed themselves.  It is safe for concurrent access.
+type MemorySource struct {
+	mtx   sync.RWMutex
+	rates map[string]*Rate
+}
+
+// Ensure MemorySource implements the Source interface.
+var _ Source = (*MemorySource)(nil)
+
+// NewMemorySource returns a new in-memory rate source which holds the passed
+// exchange rates.
+func NewMemorySource(rates ...*Rate) *MemorySource {
+	s := &MemorySource{rates: make(map[string]*Rate, len(rates))}
+	for _, rate := range rates {
+		s.rates[rate.currency.Code] = rate
+	}
+	return s
+}
+
+// SetRate sets the exchange rate for the currency of the passed rate,
+// replacing any existing rate for it.
+func (s *MemorySource) SetRate(rate *Rate) {
+	s.mtx.Lock()
+	s.rates[rate.currency.Code] = rate
+	s.mtx.Unlock()
+}
+
+// RemoveRate removes the exchange rate for the currency with the passed code.
+func (s *MemorySource) RemoveRate(code string) {
+	s.mtx.Lock()
+	delete(s.rates, code)
+	s.mtx.Unlock()
+}
+
+// Rate returns the exchange rate for the currency with the passed code or
+// ErrRateNotFound when there is none.  It is part of the Source interface
+// implementation.
+func (s *MemorySource) Rate(code string) (*Rate, error) {
+	s.mtx.RLock()
+	rate, ok := s.rates[code]
+	s.mtx.RUnlock()
+	if !ok {
+		return nil, ErrRateNotFound
+	}
+	return rate, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package fiat_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/abcsuite/abcutil/fiat"
+)
+
+// TestMemorySource ensures the in-memory rate source returns, replaces, and
+// removes rates and is safe for concurrent access.
+func TestMemorySource(t *testing.T) {
+	usd := mustParseRate(t, fiat.USD, "12.34")
+	eur := mustParseRate(t, fiat.EUR, "10")
+	var source fiat.Source = fiat.NewMemorySource(usd, eur)
+	s := source.(*fiat.MemorySource)
+
+	for _, want := range []*fiat.Rate{usd, eur} {
+		rate, err := source.Rate(want.Currency().Code)
+		if err != nil {
+			t.Errorf("Rate(%v): unexpected error: %v", want.Currency(), err)
+			continue
+		}
+		if rate != want {
+			t.Errorf("Rate(%v): mismatched rate -- got %v, want %v",
+				want.Currency(), rate, want)
+		}
+	}
+	if _, err := source.Rate("JPY"); err != fiat.ErrRateNotFound {
+		t.Errorf("Rate(JPY): mismatched error -- got %v, want %v", err,
+			fiat.ErrRateNotFound)
+	}
+
+	updated := mustParseRate(t, fiat.USD, "13")
+	s.SetRate(updated)
+	if rate, _ := source.Rate("USD"); rate != updated {
+		t.Errorf("Rate(USD): mismatched rate after update -- got %v, "+
+			"want %v", rate, updated)
+	}
+	s.RemoveRate("EUR")
+	if _, err := source.Rate("EUR"); err != fiat.ErrRateNotFound {
+		t.Errorf("Rate(EUR): mismatched error after removal -- got %v, "+
+			"want %v", err, fiat.ErrRateNotFound)
+	}
+
+	// Update and query rates concurrently so the race detector can find
+	// unsynchronized access.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.SetRate(usd)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, err := source.Rate("USD"); err != nil {
+					t.Errorf("Rate(USD): unexpected error: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
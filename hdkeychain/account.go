@@ -0,0 +1,162 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain
+
+// References:
+//   [BIP44]: BIP0044 - Multi-Account Hierarchy for Deterministic Wallets
+//   https://github.com/bitcoin/bips/blob/master/bip-0044.mediawiki
+
+import (
+	"errors"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcutil"
+)
+
+const (
+	// BIP44Purpose is the purpose index used at the first level of the
+	// [BIP44] hierarchy.  It is always derived as a hardened index.
+	BIP44Purpose = 44
+
+	// ExternalBranch is the branch index of the external (receiving)
+	// chain of an account.
+	ExternalBranch uint32 = 0
+
+	// InternalBranch is the branch index of the internal (change) chain of
+	// an account.
+	InternalBranch uint32 = 1
+
+	// MaxAccountNum is the maximum account number which may be derived.
+	// Accounts are hardened children, so the account number itself must
+	// fit in the non-hardened index range.
+	MaxAccountNum = HardenedKeyStart - 1
+
+	// maxPreallocAddresses is the maximum number of addresses for which
+	// DeriveAddresses preallocates space.  Larger counts grow the slice as
+	// addresses are derived.
+	maxPreallocAddresses = 1000
+)
+
+var (
+	// ErrInvalidAccount describes an error in which the caller attempted
+	// to derive an account number greater than MaxAccountNum.
+	ErrInvalidAccount = errors.New("account number exceeds the maximum " +
+		"allowed account number")
+
+	// ErrInvalidBranch describes an error in which the caller attempted
+	// to derive a branch other than ExternalBranch or InternalBranch.
+	ErrInvalidBranch = errors.New("branch must be either the external " +
+		"or internal branch")
+
+	// ErrBranchExhausted describes an error in which there are no
+	// remaining non-hardened child indices on a branch from which to
+	// derive further addresses.
+	ErrBranchExhausted = errors.New("no remaining child indices on branch")
+)
+
+// CoinTypePath returns the [BIP44] derivation path of the coin type
+// extended key for the passed network, m/44'/<coin type>'.
+func CoinTypePath(net *chaincfg.Params) DerivationPath {
+	return DerivationPath{
+		HardenedKeyStart + BIP44Purpose,
+		HardenedKeyStart + net.HDCoinType,
+	}
+}
+
+// AccountPath returns the [BIP44] derivation path of the passed account for
+// the passed network, m/44'/<coin type>'/<account>'.
+func AccountPath(net *chaincfg.Params, account uint32) (DerivationPath, error) {
+	if account > MaxAccountNum {
+		return nil, ErrInvalidAccount
+	}
+
+	return append(CoinTypePath(net), HardenedKeyStart+account), nil
+}
+
+// DeriveCoinTypeKey derives the [BIP44] coin type extended key for the passed
+// network from a master extended private key.
+func DeriveCoinTypeKey(master *ExtendedKey, net *chaincfg.Params) (*ExtendedKey, error) {
+	return master.DerivePath(CoinTypePath(net))
+}
+
+// DeriveAccountKey derives the [BIP44] account extended key for the passed
+// network and account number from a master extended private key.  The
+// returned key is an extended private key; use Neuter to obtain the account
+// extended public key which may be shared to derive addresses.
+//
+// Errors encountered while deriving the path are returned as a *PathError
+// identifying the failing level.  In the extremely unlikely case that the
+// error wraps ErrInvalidChild, the caller is expected to use the next account
+// number.
+func DeriveAccountKey(master *ExtendedKey, net *chaincfg.Params,
+	account uint32) (*ExtendedKey, error) {
+
+	path, err := AccountPath(net, account)
+	if err != nil {
+		return nil, err
+	}
+	return master.DerivePath(path)
+}
+
+// DeriveBranchKey derives the external or internal branch extended key from
+// an account extended key.  The account key may be either a private or public
+// extended key, and the derived branch key will be of the same kind.
+func DeriveBranchKey(acctKey *ExtendedKey, branch uint32) (*ExtendedKey, error) {
+	if branch != ExternalBranch && branch != InternalBranch {
+		return nil, ErrInvalidBranch
+	}
+
+	return acctKey.Child(branch)
+}
+
+// DeriveAddresses derives count pay-to-pubkey-hash addresses for the passed
+// network from a branch extended key, starting at child index start.
+//
+// Child indices which derive to an invalid child (ErrInvalidChild) are skipped
+// automatically, so the returned addresses may span more than count indices.
+// The child index following the last derived address is returned so that
+// callers can resume derivation from it.  ErrBranchExhausted is returned when
+// the range would extend into the hardened index range.
+func DeriveAddresses(branchKey *ExtendedKey, net *chaincfg.Params, start,
+	count uint32) ([]*abcutil.AddressPubKeyHash, uint32, error) {
+
+	// Every address requires at least one child index, so reject ranges
+	// which can not fit before the hardened index range up front.
+	if start >= HardenedKeyStart || count > HardenedKeyStart-start {
+		return nil, 0, ErrBranchExhausted
+	}
+
+	// Only preallocate a bounded number of addresses since the count is
+	// provided by the caller.
+	capacity := count
+	if capacity > maxPreallocAddresses {
+		capacity = maxPreallocAddresses
+	}
+	addrs := make([]*abcutil.AddressPubKeyHash, 0, capacity)
+	index := start
+	for uint32(len(addrs)) < count {
+		if index >= HardenedKeyStart {
+			return nil, 0, ErrBranchExhausted
+		}
+
+		child, err := branchKey.Child(index)
+		if err == ErrInvalidChild {
+			index++
+			continue
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		addr, err := child.Address(net)
+		if err != nil {
+			return nil, 0, err
+		}
+		addrs = append(addrs, addr)
+		index++
+	}
+
+	return addrs, index, nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcutil/hdkeychain"
+)
+
+// TestAccountDerivation ensures the BIP0044 account, branch, and address
+// helpers derive the same keys as the equivalent sequence of calls to Child.
+func TestAccountDerivation(t *testing.T) {
+	masterSeed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	if err != nil {
+		t.Fatalf("DecodeString: unexpected error: %v", err)
+	}
+	net := &chaincfg.MainNetParams
+	master, err := hdkeychain.NewMaster(masterSeed, net)
+	if err != nil {
+		t.Fatalf("NewMaster: unexpected error: %v", err)
+	}
+
+	// Derive m/44'/<coin type>'/1'/0 by hand for comparison.
+	wantBranch := master
+	for _, index := range []uint32{
+		hdkeychain.HardenedKeyStart + hdkeychain.BIP44Purpose,
+		hdkeychain.HardenedKeyStart + net.HDCoinType,
+		hdkeychain.HardenedKeyStart + 1,
+		hdkeychain.ExternalBranch,
+	} {
+		wantBranch, err = wantBranch.Child(index)
+		if err != nil {
+			t.Fatalf("Child: unexpected error: %v", err)
+		}
+	}
+
+	acctKey, err := hdkeychain.DeriveAccountKey(master, net, 1)
+	if err != nil {
+		t.Fatalf("DeriveAccountKey: unexpected error: %v", err)
+	}
+	acctPub, err := acctKey.Neuter()
+	if err != nil {
+		t.Fatalf("Neuter: unexpected error: %v", err)
+	}
+	branchKey, err := hdkeychain.DeriveBranchKey(acctPub, hdkeychain.ExternalBranch)
+	if err != nil {
+		t.Fatalf("DeriveBranchKey: unexpected error: %v", err)
+	}
+	wantBranchPub, _ := wantBranch.Neuter()
+	got, _ := branchKey.String()
+	want, _ := wantBranchPub.String()
+	if got != want {
+		t.Errorf("DeriveBranchKey: mismatched branch key -- got %s, "+
+			"want %s", got, want)
+	}
+
+	addrs, next, err := hdkeychain.DeriveAddresses(branchKey, net, 5, 3)
+	if err != nil {
+		t.Fatalf("DeriveAddresses: unexpected error: %v", err)
+	}
+	if len(addrs) != 3 || next != 8 {
+		t.Fatalf("DeriveAddresses: mismatched result -- got %d addresses "+
+			"and next index %d, want 3 and 8", len(addrs), next)
+	}
+	for i, addr := range addrs {
+		child, err := wantBranch.Child(uint32(5 + i))
+		if err != nil {
+			t.Fatalf("Child: unexpected error: %v", err)
+		}
+		wantAddr, err := child.Address(net)
+		if err != nil {
+			t.Fatalf("Address: unexpected error: %v", err)
+		}
+		if addr.EncodeAddress() != wantAddr.EncodeAddress() {
+			t.Errorf("DeriveAddresses #%d: mismatched address -- got "+
+				"%s, want %s", i, addr.EncodeAddress(),
+				wantAddr.EncodeAddress())
+		}
+	}
+
+	// Counts beyond the preallocated capacity grow the result as needed.
+	addrs, next, err = hdkeychain.DeriveAddresses(branchKey, net, 0, 1001)
+	if err != nil {
+		t.Fatalf("DeriveAddresses: unexpected error: %v", err)
+	}
+	if len(addrs) != 1001 || next < 1001 {
+		t.Fatalf("DeriveAddresses: mismatched result -- got %d addresses "+
+			"and next index %d, want 1001", len(addrs), next)
+	}
+}
+
+// TestAccountDerivationErrors ensures the BIP0044 helpers reject invalid
+// accounts, branches, and address ranges.
+func TestAccountDerivationErrors(t *testing.T) {
+	masterSeed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	if err != nil {
+		t.Fatalf("DecodeString: unexpected error: %v", err)
+	}
+	net := &chaincfg.MainNetParams
+	master, err := hdkeychain.NewMaster(masterSeed, net)
+	if err != nil {
+		t.Fatalf("NewMaster: unexpected error: %v", err)
+	}
+
+	_, err = hdkeychain.DeriveAccountKey(master, net, hdkeychain.MaxAccountNum+1)
+	if err != hdkeychain.ErrInvalidAccount {
+		t.Errorf("DeriveAccountKey: mismatched error -- got %v, want %v",
+			err, hdkeychain.ErrInvalidAccount)
+	}
+
+	// Accounts are hardened, so they can't be derived from a public key.
+	pub, err := master.Neuter()
+	if err != nil {
+		t.Fatalf("Neuter: unexpected error: %v", err)
+	}
+	_, err = hdkeychain.DeriveAccountKey(pub, net, 0)
+	pathErr, ok := err.(*hdkeychain.PathError)
+	if !ok || pathErr.Err != hdkeychain.ErrDeriveHardFromPublic {
+		t.Errorf("DeriveAccountKey: mismatched error -- got %v, want %v",
+			err, hdkeychain.ErrDeriveHardFromPublic)
+	}
+
+	_, err = hdkeychain.DeriveBranchKey(master, 2)
+	if err != hdkeychain.ErrInvalidBranch {
+		t.Errorf("DeriveBranchKey: mismatched error -- got %v, want %v",
+			err, hdkeychain.ErrInvalidBranch)
+	}
+
+	// Ranges which extend into the hardened index range must be rejected,
+	// including huge counts which must not be allocated.
+	exhaustedTests := []struct {
+		start uint32
+		count uint32
+	}{
+		{hdkeychain.HardenedKeyStart - 1, 2},
+		{hdkeychain.HardenedKeyStart, 1},
+		{0, hdkeychain.HardenedKeyStart + 1},
+		{0, ^uint32(0)},
+	}
+	for _, test := range exhaustedTests {
+		_, _, err = hdkeychain.DeriveAddresses(pub, net, test.start,
+			test.count)
+		if err != hdkeychain.ErrBranchExhausted {
+			t.Errorf("DeriveAddresses(%d, %d): mismatched error -- "+
+				"got %v, want %v", test.start, test.count, err,
+				hdkeychain.ErrBranchExhausted)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain
+
+import (
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcutil"
+)
+
+// DefaultGapLimit is the number of consecutive unused addresses after which
+// a branch is considered to have no further used addresses when no gap limit
+// is specified.  This is the gap limit recommended by [BIP44].
+const DefaultGapLimit = 20
+
+// AddressUsageChecker is the interface which must be implemented by callers of
+// the address discovery functions to report whether or not an address has
+// been used, for example by querying a blockchain index or a wallet database.
+type AddressUsageChecker interface {
+	// IsUsed returns whether or not the passed address has been used.  A
+	// non-nil error aborts the scan and is returned to the caller.
+	IsUsed(addr *abcutil.AddressPubKeyHash) (bool, error)
+}
+
+// DiscoveredAddress is a used address found during address discovery along
+// with the child index it was derived from.
+type DiscoveredAddress struct {
+	Index   uint32
+	Address *abcutil.AddressPubKeyHash
+}
+
+// BranchScanResult houses the result of scanning a single branch for used
+// addresses.
+type BranchScanResult struct {
+	// Found specifies whether or not any used address was found on the
+	// branch.  LastUsed is only meaningful when it is true.
+	Found bool
+
+	// LastUsed is the child index of the last used address on the branch.
+	LastUsed uint32
+
+	// Addresses are the used addresses found on the branch ordered by
+	// child index.
+	Addresses []DiscoveredAddress
+}
+
+// AccountScanResult houses the result of scanning both branches of an account
+// for used addresses.
+type AccountScanResult struct {
+	External BranchScanResult
+	Internal BranchScanResult
+}
+
+// AddressScanner discovers the used addresses of [BIP44] accounts by deriving
+// addresses from each branch until a run of gap limit consecutive unused
+// addresses is encountered.
+type AddressScanner struct {
+	net      *chaincfg.Params
+	gapLimit uint32
+	checker  AddressUsageChecker
+}
+
+// NewAddressScanner returns a new address scanner which derives addresses for
+// the passed network and queries the passed checker to determine whether or
+// not each is used.  A gap limit of zero selects DefaultGapLimit.
+func NewAddressScanner(net *chaincfg.Params, gapLimit uint32,
+	checker AddressUsageChecker) *AddressScanner {
+
+	if gapLimit == 0 {
+		gapLimit = DefaultGapLimit
+	}
+	return &AddressScanner{
+		net:      net,
+		gapLimit: gapLimit,
+		checker:  checker,
+	}
+}
+
+// ScanBranch scans a branch extended key for used addresses starting at child
+// index zero.  Scanning stops once gap limit consecutive unused addresses have
+// been checked or the non-hardened index range is exhausted.  Indices which
+// derive to an invalid child are skipped and do not count towards the gap.
+func (s *AddressScanner) ScanBranch(branchKey *ExtendedKey) (*BranchScanResult, error) {
+	var result BranchScanResult
+	var unused uint32
+	for index := uint32(0); index < HardenedKeyStart && unused < s.gapLimit; index++ {
+		child, err := branchKey.Child(index)
+		if err == ErrInvalidChild {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		addr, err := child.Address(s.net)
+		if err != nil {
+			return nil, err
+		}
+		used, err := s.checker.IsUsed(addr)
+		if err != nil {
+			return nil, err
+		}
+		if !used {
+			unused++
+			continue
+		}
+
+		unused = 0
+		result.Found = true
+		result.LastUsed = index
+		result.Addresses = append(result.Addresses,
+			DiscoveredAddress{Index: index, Address: addr})
+	}
+
+	return &result, nil
+}
+
+// ScanAccount scans both the external and internal branches of an account
+// extended key for used addresses.  The account key may be either a private or
+// public extended key.
+func (s *AddressScanner) ScanAccount(acctKey *ExtendedKey) (*AccountScanResult, error) {
+	var result AccountScanResult
+	branches := []struct {
+		branch uint32
+		result *BranchScanResult
+	}{
+		{ExternalBranch, &result.External},
+		{InternalBranch, &result.Internal},
+	}
+	for _, b := range branches {
+		branchKey, err := DeriveBranchKey(acctKey, b.branch)
+		if err != nil {
+			return nil, err
+		}
+		branchResult, err := s.ScanBranch(branchKey)
+		if err != nil {
+			return nil, err
+		}
+		*b.result = *branchResult
+	}
+
+	return &result, nil
+}
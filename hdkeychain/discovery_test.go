@@ -0,0 +1,144 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcutil"
+	"github.com/abcsuite/abcutil/hdkeychain"
+)
+
+// usedSet is an in-memory AddressUsageChecker keyed by encoded address.
+type usedSet struct {
+	used map[string]struct{}
+	err  error
+}
+
+func (s *usedSet) IsUsed(addr *abcutil.AddressPubKeyHash) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	_, ok := s.used[addr.EncodeAddress()]
+	return ok, nil
+}
+
+// TestAddressScanner ensures address discovery finds the used addresses on
+// both branches of an account and honors the gap limit.
+func TestAddressScanner(t *testing.T) {
+	masterSeed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	if err != nil {
+		t.Fatalf("DecodeString: unexpected error: %v", err)
+	}
+	net := &chaincfg.MainNetParams
+	master, err := hdkeychain.NewMaster(masterSeed, net)
+	if err != nil {
+		t.Fatalf("NewMaster: unexpected error: %v", err)
+	}
+	acctKey, err := hdkeychain.DeriveAccountKey(master, net, 0)
+	if err != nil {
+		t.Fatalf("DeriveAccountKey: unexpected error: %v", err)
+	}
+	acctPub, err := acctKey.Neuter()
+	if err != nil {
+		t.Fatalf("Neuter: unexpected error: %v", err)
+	}
+
+	branchAddrs := func(branch uint32) []*abcutil.AddressPubKeyHash {
+		branchKey, err := hdkeychain.DeriveBranchKey(acctPub, branch)
+		if err != nil {
+			t.Fatalf("DeriveBranchKey: unexpected error: %v", err)
+		}
+		addrs, _, err := hdkeychain.DeriveAddresses(branchKey, net, 0, 20)
+		if err != nil {
+			t.Fatalf("DeriveAddresses: unexpected error: %v", err)
+		}
+		return addrs
+	}
+	external := branchAddrs(hdkeychain.ExternalBranch)
+	internal := branchAddrs(hdkeychain.InternalBranch)
+
+	// With a gap limit of 5, index 14 on the external branch is beyond the
+	// gap following index 8 and must not be discovered.
+	checker := &usedSet{used: make(map[string]struct{})}
+	for _, addr := range []*abcutil.AddressPubKeyHash{external[0],
+		external[3], external[8], external[14], internal[1]} {
+
+		checker.used[addr.EncodeAddress()] = struct{}{}
+	}
+
+	scanner := hdkeychain.NewAddressScanner(net, 5, checker)
+	result, err := scanner.ScanAccount(acctPub)
+	if err != nil {
+		t.Fatalf("ScanAccount: unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		got     hdkeychain.BranchScanResult
+		addrs   []*abcutil.AddressPubKeyHash
+		indices []uint32
+	}{
+		{
+			name:    "external",
+			got:     result.External,
+			addrs:   external,
+			indices: []uint32{0, 3, 8},
+		},
+		{
+			name:    "internal",
+			got:     result.Internal,
+			addrs:   internal,
+			indices: []uint32{1},
+		},
+	}
+	for i, test := range tests {
+		lastUsed := test.indices[len(test.indices)-1]
+		if !test.got.Found || test.got.LastUsed != lastUsed {
+			t.Errorf("ScanAccount #%d (%s): mismatched last used index "+
+				"-- got %d (found %v), want %d", i, test.name,
+				test.got.LastUsed, test.got.Found, lastUsed)
+			continue
+		}
+
+		var gotIndices []uint32
+		for _, found := range test.got.Addresses {
+			gotIndices = append(gotIndices, found.Index)
+			want := test.addrs[found.Index].EncodeAddress()
+			if found.Address.EncodeAddress() != want {
+				t.Errorf("ScanAccount #%d (%s): mismatched address "+
+					"at index %d -- got %s, want %s", i, test.name,
+					found.Index, found.Address.EncodeAddress(), want)
+			}
+		}
+		if !reflect.DeepEqual(gotIndices, test.indices) {
+			t.Errorf("ScanAccount #%d (%s): mismatched indices -- got "+
+				"%v, want %v", i, test.name, gotIndices, test.indices)
+		}
+	}
+
+	// A branch without any used addresses reports none found.
+	empty := hdkeychain.NewAddressScanner(net, 0,
+		&usedSet{used: make(map[string]struct{})})
+	result, err = empty.ScanAccount(acctPub)
+	if err != nil {
+		t.Fatalf("ScanAccount: unexpected error: %v", err)
+	}
+	if result.External.Found || len(result.External.Addresses) != 0 {
+		t.Errorf("ScanAccount: unexpected used addresses on empty account")
+	}
+
+	// Errors from the checker abort the scan.
+	errChecker := errors.New("checker failure")
+	failing := hdkeychain.NewAddressScanner(net, 0, &usedSet{err: errChecker})
+	if _, err := failing.ScanAccount(acctPub); err != errChecker {
+		t.Errorf("ScanAccount: mismatched error -- got %v, want %v", err,
+			errChecker)
+	}
+}
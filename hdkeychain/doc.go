@@ -37,6 +37,15 @@ package provides the NewMaster function to create it from a cryptographically
 random seed.  The GenerateSeed function is provided as a convenient way to
 create a random seed for use with the NewMaster function.
 
+Signature Suites
+
+Extended keys are secp256k1 keys by default.  The NewMasterForSuite function
+creates a master node for the Ed25519 (chainec.ECTypeEdwards) or secp256k1
+Schnorr (chainec.ECTypeSecSchnorr) signature suites instead, and every key
+derived from it uses the same suite.  The Address function returns the
+pay-to-pubkey-hash address type which matches the suite, and the suite of an
+extended key is recorded when it is serialized.
+
 Deriving Children
 
 Once you have created a tree root (or have deserialized an extended key as
@@ -47,6 +56,38 @@ the HardenedKeyStart constant + the hardened key number as the index to the
 Child function.  This provides the ability to cascade the keys into a tree and
 hence generate the hierarchical deterministic key chains.
 
+Derivation Paths
+
+Rather than calling Child one index at a time, a descendant extended key can be
+derived with the DerivePath function.  Paths are parsed from their textual form,
+such as "m/44'/0'/1/5", with the ParsePath function, where a trailing "'" or "h"
+marks a hardened index.  The path is validated before any derivation takes
+place and failures are reported as a PathError which identifies the segment of
+the path that failed.
+
+Accounts, Branches, and Addresses
+
+The DeriveAccountKey, DeriveBranchKey, and DeriveAddresses functions implement
+the BIP0044 hierarchy m/44'/<coin type>'/<account>'/<branch>/<index> using the
+HD coin type of the passed network parameters.  Each account has an external
+branch for receiving addresses and an internal branch for change.  Addresses
+are derived from a branch as pay-to-pubkey-hash addresses, and any index which
+derives to an invalid child is skipped.
+
+The used addresses of an account can be discovered with an AddressScanner,
+which walks both branches until a run of unused addresses as long as the
+configured gap limit is found.  Whether or not an address is used is determined
+by a caller-supplied AddressUsageChecker.
+
+Key Origins
+
+Master nodes and the keys derived from them, including neutered keys, carry
+their full derivation path from the master node along with the fingerprint of
+the master node.  These are available through the Path and MasterFingerprint
+functions.  The Descriptor function returns an origin descriptor of the form
+"[fingerprint/path]apub..." which may be shared with other parties and parsed
+back with the NewKeyFromDescriptor function.
+
 Normal vs Hardened Child Extended Keys
 
 A private extended key can be used to derive both hardened and non-hardened
@@ -75,6 +116,24 @@ looks like the following:
 	public key:   xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw
 	private key:  xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7
 
+Extended keys also implement the encoding.BinaryMarshaler and
+encoding.TextMarshaler interfaces along with their unmarshalling counterparts.
+The binary form is the 78-byte serialized key without the Base58 encoding or
+checksum, while the text form, which is also used for JSON, is the
+Base58-encoded string.  The fields of an extended key are available through the
+Depth, ChildIndex, ChainCode, Version, and ParentFingerprint functions.
+
+Encrypting Extended Keys
+
+Extended private keys should not be stored in plain text.  The EncryptKey
+function encrypts an extended key under a passphrase into a versioned envelope
+which is suitable for storing at rest, and the DecryptKey function recovers the
+extended key.  The encryption key is derived from the passphrase with the
+memory-hard scrypt function using the parameters recorded in the envelope, and
+the key is encrypted and authenticated with NaCl secretbox.  DecryptKey returns
+ErrWrongPassphrase when the passphrase is incorrect and ErrCorruptEncryptedKey
+when the envelope has been damaged.
+
 Network
 
 Extended keys are much like normal Aero addresses in that they have version
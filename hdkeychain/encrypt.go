@@ -0,0 +1,241 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	"github.com/abcsuite/abcd/chaincfg/chainhash"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// EncryptedKeyVersion is the version of the envelope produced by
+	// EncryptKey.
+	EncryptedKeyVersion = 1
+
+	// encSaltLen is the length of the random scrypt salt.
+	encSaltLen = 32
+
+	// encNonceLen is the length of the random secretbox nonce.
+	encNonceLen = 24
+
+	// encKeyLen is the length of the secretbox encryption key.
+	encKeyLen = 32
+
+	// encVerifierLen is the length of the passphrase verifier.
+	encVerifierLen = 32
+
+	// encChecksumLen is the length of the envelope checksum.
+	encChecksumLen = 4
+
+	// maxKDFLogN is the maximum base 2 logarithm of the scrypt cost
+	// parameter N.  Larger values exceed maxKDFMemory for every R.
+	maxKDFLogN = 23
+
+	// maxKDFMemory is the maximum number of bytes scrypt may require for
+	// the parameters, which is 128 * R * N.  Since the envelope checksum
+	// is not keyed, this bounds the memory a crafted envelope can cause
+	// DecryptKey to allocate.
+	maxKDFMemory = 1 << 30
+
+	// maxKDFP is the maximum scrypt parallelization parameter.  It bounds
+	// the work a crafted envelope can cause DecryptKey to perform.
+	maxKDFP = 16
+
+	// encHeaderLen is the length of the envelope header.  It consists of
+	// 1 byte version, 1 byte scrypt log2(N), 1 byte scrypt r, 1 byte
+	// scrypt p, 32 bytes salt, 24 bytes nonce, and 32 bytes passphrase
+	// verifier.
+	encHeaderLen = 1 + 1 + 1 + 1 + encSaltLen + encNonceLen + encVerifierLen
+)
+
+var (
+	// ErrWrongPassphrase describes an error in which an encrypted extended
+	// key could not be decrypted because the passphrase is incorrect.
+	ErrWrongPassphrase = errors.New("wrong passphrase for encrypted " +
+		"extended key")
+
+	// ErrCorruptEncryptedKey describes an error in which an encrypted
+	// extended key is truncated, fails its checksum, or otherwise fails to
+	// authenticate with the correct passphrase.
+	ErrCorruptEncryptedKey = errors.New("encrypted extended key is corrupt")
+
+	// ErrUnsupportedEncryptedKeyVersion describes an error in which an
+	// encrypted extended key uses an envelope version which is not
+	// supported by this package.
+	ErrUnsupportedEncryptedKeyVersion = errors.New("unsupported encrypted " +
+		"extended key version")
+
+	// ErrInvalidKDFParams describes an error in which the scrypt parameters
+	// used to encrypt an extended key are out of range.
+	ErrInvalidKDFParams = errors.New("invalid key derivation parameters")
+)
+
+// KDFParams houses the scrypt parameters used to derive the encryption key of
+// an encrypted extended key from a passphrase.  The parameters are stored in
+// the envelope so they may be strengthened over time without losing the
+// ability to decrypt existing keys.
+type KDFParams struct {
+	// LogN is the base 2 logarithm of the scrypt CPU/memory cost parameter
+	// N.  It must be between 1 and 23.
+	LogN uint8
+
+	// R is the scrypt block size parameter.  It must be at least 1, and
+	// the memory required by scrypt, 128 * R * 2^LogN bytes, must not
+	// exceed 1 GiB.
+	R uint8
+
+	// P is the scrypt parallelization parameter.  It must be between 1 and
+	// 16.
+	P uint8
+}
+
+// DefaultKDFParams are the recommended scrypt parameters for encrypting
+// extended keys.  Deriving a key with them requires 256 MiB of memory.
+var DefaultKDFParams = KDFParams{LogN: 18, R: 8, P: 1}
+
+// validate returns ErrInvalidKDFParams when the parameters are out of range.
+func (p *KDFParams) validate() error {
+	if p.LogN < 1 || p.LogN > maxKDFLogN || p.R < 1 || p.P < 1 ||
+		p.P > maxKDFP {
+
+		return ErrInvalidKDFParams
+	}
+	if 128*uint64(p.R)<<p.LogN > maxKDFMemory {
+		return ErrInvalidKDFParams
+	}
+	return nil
+}
+
+// deriveKeys derives the encryption key and passphrase verifier from the
+// passed passphrase and salt.  The returned slice must be zeroed by the caller
+// once it is no longer needed.
+func (p *KDFParams) deriveKeys(passphrase, salt []byte) ([]byte, error) {
+	return scrypt.Key(passphrase, salt, 1<<p.LogN, int(p.R), int(p.P),
+		encKeyLen+encVerifierLen)
+}
+
+// EncryptKey encrypts the passed extended key under the passphrase and returns
+// a versioned envelope which is safe to store at rest.  The encryption key is
+// derived from the passphrase with scrypt using the passed parameters, or
+// DefaultKDFParams when they are nil, and the serialized extended key is
+// encrypted and authenticated with NaCl secretbox (XSalsa20 and Poly1305).
+//
+// The envelope format is:
+//   version (1) || scrypt log2(N) (1) || scrypt r (1) || scrypt p (1) ||
+//   salt (32) || nonce (24) || verifier (32) || ciphertext || checksum (4)
+//
+// All intermediate key material is zeroed before returning.
+func EncryptKey(key *ExtendedKey, passphrase []byte, params *KDFParams) ([]byte, error) {
+	if params == nil {
+		params = &DefaultKDFParams
+	}
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	plaintext, err := key.serialize()
+	if err != nil {
+		return nil, err
+	}
+	defer zero(plaintext)
+
+	envelope := make([]byte, encHeaderLen, encHeaderLen+len(plaintext)+
+		secretbox.Overhead+encChecksumLen)
+	envelope[0] = EncryptedKeyVersion
+	envelope[1] = params.LogN
+	envelope[2] = params.R
+	envelope[3] = params.P
+	saltAndNonce := envelope[4 : 4+encSaltLen+encNonceLen]
+	if _, err := io.ReadFull(rand.Reader, saltAndNonce); err != nil {
+		return nil, err
+	}
+	salt := saltAndNonce[:encSaltLen]
+	nonce := saltAndNonce[encSaltLen:]
+
+	derived, err := params.deriveKeys(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(derived)
+	copy(envelope[4+encSaltLen+encNonceLen:], derived[encKeyLen:])
+
+	var encKey [encKeyLen]byte
+	var nonceArr [encNonceLen]byte
+	copy(encKey[:], derived[:encKeyLen])
+	copy(nonceArr[:], nonce)
+	envelope = secretbox.Seal(envelope, plaintext, &nonceArr, &encKey)
+	zero(encKey[:])
+
+	checkSum := chainhash.HashB(chainhash.HashB(envelope))[:encChecksumLen]
+	return append(envelope, checkSum...), nil
+}
+
+// DecryptKey decrypts an envelope produced by EncryptKey with the passed
+// passphrase and returns the extended key it contains.
+//
+// ErrWrongPassphrase is returned when the envelope is intact but the
+// passphrase is incorrect, while ErrCorruptEncryptedKey is returned when the
+// envelope has been truncated or modified.  All intermediate key material is
+// zeroed before returning.
+func DecryptKey(envelope, passphrase []byte) (*ExtendedKey, error) {
+	minLen := encHeaderLen + secretbox.Overhead + encChecksumLen
+	if len(envelope) < minLen {
+		return nil, ErrCorruptEncryptedKey
+	}
+
+	// Ensure the envelope is intact before attempting to interpret it so
+	// corruption is never mistaken for an incorrect passphrase.
+	payload := envelope[:len(envelope)-encChecksumLen]
+	checkSum := envelope[len(envelope)-encChecksumLen:]
+	expectedCheckSum := chainhash.HashB(chainhash.HashB(payload))[:encChecksumLen]
+	if !bytes.Equal(checkSum, expectedCheckSum) {
+		return nil, ErrCorruptEncryptedKey
+	}
+
+	if payload[0] != EncryptedKeyVersion {
+		return nil, ErrUnsupportedEncryptedKeyVersion
+	}
+	params := KDFParams{LogN: payload[1], R: payload[2], P: payload[3]}
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	salt := payload[4 : 4+encSaltLen]
+	nonce := payload[4+encSaltLen : 4+encSaltLen+encNonceLen]
+	verifier := payload[4+encSaltLen+encNonceLen : encHeaderLen]
+	ciphertext := payload[encHeaderLen:]
+
+	derived, err := params.deriveKeys(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(derived)
+	if subtle.ConstantTimeCompare(derived[encKeyLen:], verifier) != 1 {
+		return nil, ErrWrongPassphrase
+	}
+
+	var encKey [encKeyLen]byte
+	var nonceArr [encNonceLen]byte
+	copy(encKey[:], derived[:encKeyLen])
+	copy(nonceArr[:], nonce)
+	plaintext, ok := secretbox.Open(nil, ciphertext, &nonceArr, &encKey)
+	zero(encKey[:])
+	if !ok {
+		return nil, ErrCorruptEncryptedKey
+	}
+	defer zero(plaintext)
+
+	key := new(ExtendedKey)
+	if err := key.UnmarshalBinary(plaintext); err != nil {
+		return nil, ErrCorruptEncryptedKey
+	}
+	return key, nil
+}
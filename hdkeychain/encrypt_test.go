@@ -0,0 +1,164 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain_test
+
+import (
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg/chainhash"
+	"github.com/abcsuite/abcutil/hdkeychain"
+)
+
+// TestEncryptKey ensures extended keys round trip through encryption and that
+// wrong passphrases and corrupted envelopes are reported distinctly.
+func TestEncryptKey(t *testing.T) {
+	// Private key at m/0H/1 from [BIP32] test vector 1.
+	const serialized = "aprv2ow6HZ4jikf4WXuauc4i3B5usZmv2uuCECYLoty9RbWrWcXePU6smqtCYKLk2kz4XKSHye8BHgcxKxoziX8aJgXvuyssehc9cn4CEm7eP2F"
+	key, err := hdkeychain.NewKeyFromString(serialized)
+	if err != nil {
+		t.Fatalf("NewKeyFromString: unexpected error: %v", err)
+	}
+
+	// Use cheap parameters to keep the test fast.
+	params := &hdkeychain.KDFParams{LogN: 4, R: 8, P: 1}
+	passphrase := []byte("correct horse battery staple")
+	envelope, err := hdkeychain.EncryptKey(key, passphrase, params)
+	if err != nil {
+		t.Fatalf("EncryptKey: unexpected error: %v", err)
+	}
+	if envelope[0] != hdkeychain.EncryptedKeyVersion {
+		t.Errorf("EncryptKey: mismatched version -- got %d, want %d",
+			envelope[0], hdkeychain.EncryptedKeyVersion)
+	}
+
+	decrypted, err := hdkeychain.DecryptKey(envelope, passphrase)
+	if err != nil {
+		t.Fatalf("DecryptKey: unexpected error: %v", err)
+	}
+	if got, _ := decrypted.String(); got != serialized {
+		t.Errorf("DecryptKey: mismatched key -- got %s, want %s", got,
+			serialized)
+	}
+
+	// Encrypting the same key twice must not produce the same envelope.
+	again, err := hdkeychain.EncryptKey(key, passphrase, params)
+	if err != nil {
+		t.Fatalf("EncryptKey: unexpected error: %v", err)
+	}
+	if string(again) == string(envelope) {
+		t.Errorf("EncryptKey: envelope is not randomized")
+	}
+
+	// modify returns a copy of the envelope after applying the passed
+	// function and, when requested, recalculating the checksum so the
+	// modification is only detected by the later stages of decryption.
+	modify := func(f func(b []byte), fixChecksum bool) []byte {
+		b := append([]byte(nil), envelope...)
+		f(b)
+		if fixChecksum {
+			payload := b[:len(b)-4]
+			copy(b[len(b)-4:], chainhash.HashB(chainhash.HashB(payload))[:4])
+		}
+		return b
+	}
+
+	tests := []struct {
+		name       string
+		envelope   []byte
+		passphrase string
+		err        error
+	}{
+		{
+			name:       "wrong passphrase",
+			envelope:   envelope,
+			passphrase: "Tr0ub4dor&3",
+			err:        hdkeychain.ErrWrongPassphrase,
+		},
+		{
+			name:       "truncated",
+			envelope:   envelope[:40],
+			passphrase: string(passphrase),
+			err:        hdkeychain.ErrCorruptEncryptedKey,
+		},
+		{
+			name: "flipped ciphertext bit",
+			envelope: modify(func(b []byte) {
+				b[len(b)-10] ^= 0x01
+			}, false),
+			passphrase: string(passphrase),
+			err:        hdkeychain.ErrCorruptEncryptedKey,
+		},
+		{
+			name: "flipped ciphertext bit with valid checksum",
+			envelope: modify(func(b []byte) {
+				b[len(b)-10] ^= 0x01
+			}, true),
+			passphrase: string(passphrase),
+			err:        hdkeychain.ErrCorruptEncryptedKey,
+		},
+		{
+			name: "unsupported version",
+			envelope: modify(func(b []byte) {
+				b[0] = hdkeychain.EncryptedKeyVersion + 1
+			}, true),
+			passphrase: string(passphrase),
+			err:        hdkeychain.ErrUnsupportedEncryptedKeyVersion,
+		},
+		{
+			name: "invalid kdf params",
+			envelope: modify(func(b []byte) {
+				b[1] = 0
+			}, true),
+			passphrase: string(passphrase),
+			err:        hdkeychain.ErrInvalidKDFParams,
+		},
+		{
+			name: "kdf log n too large",
+			envelope: modify(func(b []byte) {
+				b[1] = 24
+			}, true),
+			passphrase: string(passphrase),
+			err:        hdkeychain.ErrInvalidKDFParams,
+		},
+		{
+			name: "kdf memory too large",
+			envelope: modify(func(b []byte) {
+				b[1], b[2] = 23, 255
+			}, true),
+			passphrase: string(passphrase),
+			err:        hdkeychain.ErrInvalidKDFParams,
+		},
+		{
+			name: "kdf p too large",
+			envelope: modify(func(b []byte) {
+				b[3] = 255
+			}, true),
+			passphrase: string(passphrase),
+			err:        hdkeychain.ErrInvalidKDFParams,
+		},
+	}
+	for i, test := range tests {
+		_, err := hdkeychain.DecryptKey(test.envelope, []byte(test.passphrase))
+		if err != test.err {
+			t.Errorf("DecryptKey #%d (%s): mismatched error -- got %v, "+
+				"want %v", i, test.name, err, test.err)
+		}
+	}
+
+	invalidParams := []hdkeychain.KDFParams{
+		{},
+		{LogN: 24, R: 1, P: 1},
+		{LogN: 23, R: 2, P: 1},
+		{LogN: 18, R: 255, P: 1},
+		{LogN: 4, R: 8, P: 17},
+	}
+	for _, params := range invalidParams {
+		_, err = hdkeychain.EncryptKey(key, passphrase, &params)
+		if err != hdkeychain.ErrInvalidKDFParams {
+			t.Errorf("EncryptKey(%+v): mismatched error -- got %v, "+
+				"want %v", params, err, hdkeychain.ErrInvalidKDFParams)
+		}
+	}
+}
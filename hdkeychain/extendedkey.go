@@ -50,6 +50,11 @@ const (
 	// fingerprint, 4 bytes child number, 32 bytes chain code, and 33 bytes
 	// public/private key data.
 	serializedKeyLen = 4 + 1 + 4 + 4 + 32 + 33 // 78 bytes
+
+	// serializedSuiteKeyLen is the length of a serialized public or private
+	// extended key for a signature suite other than secp256k1.  It consists
+	// of a serialized extended key followed by 1 byte signature suite.
+	serializedSuiteKeyLen = serializedKeyLen + 1 // 79 bytes
 )
 
 var (
@@ -65,15 +70,15 @@ var (
 
 	// ErrInvalidChild describes an error in which the child at a specific
 	// index is invalid due to the derived key falling outside of the valid
-	// range for secp256k1 private keys.  This error indicates the caller
-	// should simply ignore the invalid child extended key at this index and
-	// increment to the next index.
+	// range for private keys of the signature suite.  This error indicates
+	// the caller should simply ignore the invalid child extended key at
+	// this index and increment to the next index.
 	ErrInvalidChild = errors.New("the extended key at this index is invalid")
 
 	// ErrUnusableSeed describes an error in which the provided seed is not
 	// usable due to the derived key falling outside of the valid range for
-	// secp256k1 private keys.  This error indicates the caller must choose
-	// another seed.
+	// private keys of the signature suite.  This error indicates the
+	// caller must choose another seed.
 	ErrUnusableSeed = errors.New("unusable seed")
 
 	// ErrInvalidSeedLen describes an error in which the provided seed or
@@ -107,13 +112,15 @@ type ExtendedKey struct {
 	childNum  uint32
 	version   []byte
 	isPrivate bool
+	suite     int
+	origin    *keyOrigin // This will be nil when the origin is unknown
 }
 
 // newExtendedKey returns a new instance of an extended key with the given
 // fields.  No error checking is performed here as it's only intended to be a
 // convenience method used to create a populated struct.
 func newExtendedKey(version, key, chainCode, parentFP []byte, depth uint16,
-	childNum uint32, isPrivate bool) *ExtendedKey {
+	childNum uint32, isPrivate bool, suite int) *ExtendedKey {
 
 	// NOTE: The pubKey field is intentionally left nil so it is only
 	// computed and memoized as required.
@@ -125,12 +132,21 @@ func newExtendedKey(version, key, chainCode, parentFP []byte, depth uint16,
 		childNum:  childNum,
 		version:   version,
 		isPrivate: isPrivate,
+		suite:     suite,
 	}
 }
 
+// params returns the parameters for the signature suite of the extended
+// key.  The suite is validated whenever an extended key is created, so it is
+// always supported.
+func (k *ExtendedKey) params() *suiteParams {
+	return suites[k.suite]
+}
+
 // pubKeyBytes returns bytes for the serialized compressed public key associated
 // with this extended key in an efficient manner including memoization as
-// necessary.
+// necessary.  The public key is 33 bytes for secp256k1 based suites and 32
+// bytes for Ed25519.
 //
 // When the extended key is already a public key, the key is simply returned as
 // is since it's already in the correct form.  However, when the extended key is
@@ -145,14 +161,37 @@ func (k *ExtendedKey) pubKeyBytes() []byte {
 	// This is a private extended key, so calculate and memoize the public
 	// key if needed.
 	if len(k.pubKey) == 0 {
-		pkx, pky := chainec.Secp256k1.ScalarBaseMult(k.key)
-		pubKey := chainec.Secp256k1.NewPublicKey(pkx, pky)
+		dsa := k.params().dsa
+		pkx, pky := dsa.ScalarBaseMult(k.key)
+		pubKey := dsa.NewPublicKey(pkx, pky)
 		k.pubKey = pubKey.SerializeCompressed()
 	}
 
 	return k.pubKey
 }
 
+// pubKeyData returns the public key associated with this extended key in the
+// 33-byte form used in the key data field of a serialized extended public key
+// and when deriving non-hardened children.  The compressed public key is used
+// as is for secp256k1 based suites, while Ed25519 public keys are prefixed
+// with edwardsPubKeyPrefix.
+func (k *ExtendedKey) pubKeyData() []byte {
+	pubKey := k.pubKeyBytes()
+	if k.suite != chainec.ECTypeEdwards {
+		return pubKey
+	}
+	data := make([]byte, 0, len(pubKey)+1)
+	data = append(data, edwardsPubKeyPrefix)
+	return append(data, pubKey...)
+}
+
+// Suite returns the signature suite of the extended key, which is one of
+// chainec.ECTypeSecp256k1, chainec.ECTypeEdwards, or chainec.ECTypeSecSchnorr.
+// All keys derived from an extended key share its signature suite.
+func (k *ExtendedKey) Suite() int {
+	return k.suite
+}
+
 // IsPrivate returns whether or not the extended key is a private extended key.
 //
 // A private extended key can be used to derive both hardened and non-hardened
@@ -224,8 +263,8 @@ func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 		// Case #2 or #3.
 		// This is either a public or private extended key, but in
 		// either case, the data which is used to derive the child key
-		// starts with the secp256k1 compressed public key bytes.
-		copy(data, k.pubKeyBytes())
+		// starts with the 33-byte public key data.
+		copy(data, k.pubKeyData())
 	}
 	binary.BigEndian.PutUint32(data[keyLen:], i)
 
@@ -244,14 +283,16 @@ func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 
 	// Both derived public or private keys rely on treating the left 32-byte
 	// sequence calculated above (Il) as a 256-bit integer that must be
-	// within the valid range for a secp256k1 private key.  There is a small
-	// chance (< 1 in 2^127) this condition will not hold, and in that case,
-	// a child extended key can't be created for this index and the caller
-	// should simply increment to the next index.
-	ilNum := new(big.Int).SetBytes(il)
-	if ilNum.Cmp(chainec.Secp256k1.GetN()) >= 0 || ilNum.Sign() == 0 {
+	// within the valid range for a private key of the signature suite.
+	// There is a small chance (< 1 in 2^127) this condition will not hold,
+	// and in that case, a child extended key can't be created for this
+	// index and the caller should simply increment to the next index.
+	suite := k.params()
+	ilNum := suite.scalar(il)
+	if ilNum == nil {
 		return nil, ErrInvalidChild
 	}
+	il = paddedAppend(32, nil, ilNum.Bytes())
 
 	// The algorithm used to derive the child key depends on whether or not
 	// a private or public child is being derived.
@@ -271,14 +312,20 @@ func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 		// childKey = parse256(Il) + parenKey
 		keyNum := new(big.Int).SetBytes(k.key)
 		ilNum.Add(ilNum, keyNum)
-		ilNum.Mod(ilNum, chainec.Secp256k1.GetN())
+		ilNum.Mod(ilNum, suite.dsa.GetN())
+		if ilNum.Sign() == 0 {
+			return nil, ErrInvalidChild
+		}
 		childKey = ilNum.Bytes()
+		if !suite.unpaddedChildKeys {
+			childKey = paddedAppend(32, nil, childKey)
+		}
 		isPrivate = true
 	} else {
 		// Case #3.
 		// Calculate the corresponding intermediate public key for
 		// intermediate private key.
-		ilx, ily := chainec.Secp256k1.ScalarBaseMult(il)
+		ilx, ily := suite.dsa.ScalarBaseMult(il)
 		if ilx.Sign() == 0 || ily.Sign() == 0 {
 			return nil, ErrInvalidChild
 		}
@@ -286,7 +333,7 @@ func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 		// Convert the serialized compressed parent public key into X
 		// and Y coordinates so it can be added to the intermediate
 		// public key.
-		pubKey, err := chainec.Secp256k1.ParsePubKey(k.key)
+		pubKey, err := suite.dsa.ParsePubKey(k.key)
 		if err != nil {
 			return nil, err
 		}
@@ -295,17 +342,19 @@ func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 		// derive the final child key.
 		//
 		// childKey = serP(point(parse256(Il)) + parentKey)
-		childX, childY := chainec.Secp256k1.Add(ilx, ily, pubKey.GetX(),
+		childX, childY := suite.dsa.Add(ilx, ily, pubKey.GetX(),
 			pubKey.GetY())
-		pk := chainec.Secp256k1.NewPublicKey(childX, childY)
+		pk := suite.dsa.NewPublicKey(childX, childY)
 		childKey = pk.SerializeCompressed()
 	}
 
 	// The fingerprint of the parent for the derived child is the first 4
 	// bytes of the RIPEMD160(SHA256(parentPubKey)).
-	parentFP := abcutil.Hash160(k.pubKeyBytes())[:4]
-	return newExtendedKey(k.version, childKey, childChainCode, parentFP,
-		k.depth+1, i, isPrivate), nil
+	parentFP := k.fingerprint()
+	child := newExtendedKey(k.version, childKey, childChainCode, parentFP,
+		k.depth+1, i, isPrivate, k.suite)
+	child.origin = childOrigin(k.origin, parentFP, i)
+	return child, nil
 }
 
 // Neuter returns a new extended public key from this extended private key.  The
@@ -331,16 +380,20 @@ func (k *ExtendedKey) Neuter() (*ExtendedKey, error) {
 	// key will simply be the pubkey of the current extended private key.
 	//
 	// This is the function N((k,c)) -> (K, c) from [BIP32].
-	return newExtendedKey(version, k.pubKeyBytes(), k.chainCode, k.parentFP,
-		k.depth, k.childNum, false), nil
+	pubKey := newExtendedKey(version, k.pubKeyBytes(), k.chainCode,
+		k.parentFP, k.depth, k.childNum, false, k.suite)
+	pubKey.origin = k.origin
+	return pubKey, nil
 }
 
-// ECPubKey converts the extended key to a abcec public key and returns it.
+// ECPubKey converts the extended key to a public key of its signature suite
+// and returns it.
 func (k *ExtendedKey) ECPubKey() (chainec.PublicKey, error) {
-	return chainec.Secp256k1.ParsePubKey(k.pubKeyBytes())
+	return k.params().dsa.ParsePubKey(k.pubKeyBytes())
 }
 
-// ECPrivKey converts the extended key to a abcec private key and returns it.
+// ECPrivKey converts the extended key to a private key of its signature suite
+// and returns it.
 // As you might imagine this is only possible if the extended key is a private
 // extended key (as determined by the IsPrivate function).  The ErrNotPrivExtKey
 // error will be returned if this function is called on a public extended key.
@@ -349,15 +402,15 @@ func (k *ExtendedKey) ECPrivKey() (chainec.PrivateKey, error) {
 		return nil, ErrNotPrivExtKey
 	}
 
-	privKey, _ := chainec.Secp256k1.PrivKeyFromBytes(k.key)
-	return privKey, nil
+	return k.params().privKey(k.key), nil
 }
 
 // Address converts the extended key to a standard Aero pay-to-pubkey-hash
-// address for the passed network.
+// address for the passed network.  The address type matches the signature
+// suite of the extended key.
 func (k *ExtendedKey) Address(net *chaincfg.Params) (*abcutil.AddressPubKeyHash, error) {
 	pkHash := abcutil.Hash160(k.pubKeyBytes())
-	return abcutil.NewAddressPubKeyHash(pkHash, net, chainec.ECTypeSecp256k1)
+	return abcutil.NewAddressPubKeyHash(pkHash, net, k.suite)
 }
 
 // paddedAppend appends the src byte slice to dst, returning the new slice.
@@ -370,10 +423,10 @@ func paddedAppend(size uint, dst, src []byte) []byte {
 	return append(dst, src...)
 }
 
-// String returns the extended key as a human-readable base58-encoded string.
-func (k *ExtendedKey) String() (string, error) {
+// serialize returns the serialized extended key payload without a checksum.
+func (k *ExtendedKey) serialize() ([]byte, error) {
 	if len(k.key) == 0 {
-		return "", fmt.Errorf("zeroed extended key")
+		return nil, fmt.Errorf("zeroed extended key")
 	}
 
 	var childNumBytes [4]byte
@@ -382,8 +435,12 @@ func (k *ExtendedKey) String() (string, error) {
 
 	// The serialized format is:
 	//   version (4) || depth (1) || parent fingerprint (4)) ||
-	//   child num (4) || chain code (32) || key data (33) || checksum (4)
-	serializedBytes := make([]byte, 0, serializedKeyLen+4)
+	//   child num (4) || chain code (32) || key data (33) ||
+	//   [suite (1)]
+	//
+	// The signature suite is only included for suites other than
+	// secp256k1 so the serialization of secp256k1 keys is unchanged.
+	serializedBytes := make([]byte, 0, serializedSuiteKeyLen+4)
 	serializedBytes = append(serializedBytes, k.version...)
 	serializedBytes = append(serializedBytes, depthByte)
 	serializedBytes = append(serializedBytes, k.parentFP...)
@@ -393,7 +450,20 @@ func (k *ExtendedKey) String() (string, error) {
 		serializedBytes = append(serializedBytes, 0x00)
 		serializedBytes = paddedAppend(32, serializedBytes, k.key)
 	} else {
-		serializedBytes = append(serializedBytes, k.pubKeyBytes()...)
+		serializedBytes = append(serializedBytes, k.pubKeyData()...)
+	}
+	if k.suite != chainec.ECTypeSecp256k1 {
+		serializedBytes = append(serializedBytes, byte(k.suite))
+	}
+
+	return serializedBytes, nil
+}
+
+// String returns the extended key as a human-readable base58-encoded string.
+func (k *ExtendedKey) String() (string, error) {
+	serializedBytes, err := k.serialize()
+	if err != nil {
+		return "", err
 	}
 
 	checkSum := chainhash.HashB(chainhash.HashB(serializedBytes))[:4]
@@ -401,6 +471,80 @@ func (k *ExtendedKey) String() (string, error) {
 	return base58.Encode(serializedBytes), nil
 }
 
+// MarshalBinary satisfies the encoding.BinaryMarshaler interface.  The binary
+// form of an extended key is the 78-byte payload of its base58-encoded string
+// form (79 bytes for signature suites other than secp256k1) without the
+// checksum.
+func (k *ExtendedKey) MarshalBinary() ([]byte, error) {
+	return k.serialize()
+}
+
+// UnmarshalBinary satisfies the encoding.BinaryUnmarshaler interface.  It
+// replaces the receiver with the extended key encoded by the passed binary
+// form as produced by MarshalBinary.
+func (k *ExtendedKey) UnmarshalBinary(data []byte) error {
+	if len(data) != serializedKeyLen && len(data) != serializedSuiteKeyLen {
+		return ErrInvalidKeyLen
+	}
+
+	// Copy the data since the extended key retains references to it.
+	payload := make([]byte, len(data))
+	copy(payload, data)
+	key, err := deserialize(payload)
+	if err != nil {
+		return err
+	}
+	*k = *key
+	return nil
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.  The text form
+// of an extended key is its base58-encoded string form, which also makes it
+// the form used when an extended key is marshalled as JSON.
+func (k *ExtendedKey) MarshalText() ([]byte, error) {
+	str, err := k.String()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(str), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.  It replaces
+// the receiver with the extended key encoded by the passed base58-encoded
+// string form.
+func (k *ExtendedKey) UnmarshalText(text []byte) error {
+	key, err := NewKeyFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*k = *key
+	return nil
+}
+
+// Depth returns the depth of the extended key in the hierarchy.  The master
+// node has a depth of zero.
+func (k *ExtendedKey) Depth() uint8 {
+	return uint8(k.depth)
+}
+
+// ChildIndex returns the index at which the extended key was derived from its
+// parent.  Hardened children have indices of HardenedKeyStart and greater.  The
+// master node has an index of zero.
+func (k *ExtendedKey) ChildIndex() uint32 {
+	return k.childNum
+}
+
+// ChainCode returns a copy of the chain code of the extended key.
+func (k *ExtendedKey) ChainCode() []byte {
+	return append([]byte(nil), k.chainCode...)
+}
+
+// Version returns a copy of the version bytes of the extended key, which
+// identify both the network and whether or not it is a private key.
+func (k *ExtendedKey) Version() []byte {
+	return append([]byte(nil), k.version...)
+}
+
 // IsForNet returns whether or not the extended key is associated with the
 // passed Aero network.
 func (k *ExtendedKey) IsForNet(net *chaincfg.Params) bool {
@@ -441,17 +585,35 @@ func (k *ExtendedKey) Zero() {
 	k.depth = 0
 	k.childNum = 0
 	k.isPrivate = false
+	k.origin = nil
 }
 
-// NewMaster creates a new master node for use in creating a hierarchical
-// deterministic key chain.  The seed must be between 128 and 512 bits and
-// should be generated by a cryptographically secure random generation source.
+// NewMaster creates a new secp256k1 master node for use in creating a
+// hierarchical deterministic key chain.  The seed must be between 128 and 512
+// bits and should be generated by a cryptographically secure random generation
+// source.
 //
 // NOTE: There is an extremely small chance (< 1 in 2^127) the provided seed
 // will derive to an unusable secret key.  The ErrUnusable error will be
 // returned if this should occur, so the caller must check for it and generate a
 // new seed accordingly.
 func NewMaster(seed []byte, net *chaincfg.Params) (*ExtendedKey, error) {
+	return NewMasterForSuite(seed, net, chainec.ECTypeSecp256k1)
+}
+
+// NewMasterForSuite creates a new master node for the passed signature suite,
+// which must be one of chainec.ECTypeSecp256k1, chainec.ECTypeEdwards, or
+// chainec.ECTypeSecSchnorr.  All keys derived from the master node use the same
+// signature suite.  See NewMaster for details regarding the seed.
+//
+// Each signature suite derives its master node from the seed with a different
+// HMAC key, so a seed results in unrelated key hierarchies for each suite.
+func NewMasterForSuite(seed []byte, net *chaincfg.Params, suite int) (*ExtendedKey, error) {
+	params, err := lookupSuite(suite)
+	if err != nil {
+		return nil, err
+	}
+
 	// Per [BIP32], the seed must be in range [MinSeedBytes, MaxSeedBytes].
 	if len(seed) < MinSeedBytes || len(seed) > MaxSeedBytes {
 		return nil, ErrInvalidSeedLen
@@ -459,7 +621,9 @@ func NewMaster(seed []byte, net *chaincfg.Params) (*ExtendedKey, error) {
 
 	// First take the HMAC-SHA512 of the master key and the seed data:
 	//   I = HMAC-SHA512(Key = "Bitcoin seed", Data = S)
-	hmac512 := hmac.New(sha512.New, masterKey)
+	//
+	// The master key is specific to the signature suite as noted above.
+	hmac512 := hmac.New(sha512.New, params.masterKey)
 	hmac512.Write(seed)
 	lr := hmac512.Sum(nil)
 
@@ -470,30 +634,36 @@ func NewMaster(seed []byte, net *chaincfg.Params) (*ExtendedKey, error) {
 	chainCode := lr[len(lr)/2:]
 
 	// Ensure the key in usable.
-	secretKeyNum := new(big.Int).SetBytes(secretKey)
-	if secretKeyNum.Cmp(chainec.Secp256k1.GetN()) >= 0 ||
-		secretKeyNum.Sign() == 0 {
+	secretKeyNum := params.scalar(secretKey)
+	if secretKeyNum == nil {
 		return nil, ErrUnusableSeed
 	}
+	secretKey = paddedAppend(32, nil, secretKeyNum.Bytes())
 
 	parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-	return newExtendedKey(net.HDPrivateKeyID[:], secretKey, chainCode,
-		parentFP, 0, 0, true), nil
+	master := newExtendedKey(net.HDPrivateKeyID[:], secretKey, chainCode,
+		parentFP, 0, 0, true, suite)
+	master.origin = &keyOrigin{}
+	return master, nil
 }
 
 // NewKeyFromString returns a new extended key instance from a base58-encoded
 // extended key.
 func NewKeyFromString(key string) (*ExtendedKey, error) {
-	// The base58-decoded extended key must consist of a serialized payload
+	// The base58-decoded extended key must consist of a serialized payload,
+	// which includes the signature suite for suites other than secp256k1,
 	// plus an additional 4 bytes for the checksum.
 	decoded := base58.Decode(key)
-	if len(decoded) != serializedKeyLen+4 {
+	if len(decoded) != serializedKeyLen+4 &&
+		len(decoded) != serializedSuiteKeyLen+4 {
+
 		return nil, ErrInvalidKeyLen
 	}
 
 	// The serialized format is:
 	//   version (4) || depth (1) || parent fingerprint (4)) ||
-	//   child num (4) || chain code (32) || key data (33) || checksum (4)
+	//   child num (4) || chain code (32) || key data (33) ||
+	//   [suite (1)] || checksum (4)
 
 	// Split the payload and checksum up and ensure the checksum matches.
 	payload := decoded[:len(decoded)-4]
@@ -503,6 +673,13 @@ func NewKeyFromString(key string) (*ExtendedKey, error) {
 		return nil, ErrBadChecksum
 	}
 
+	return deserialize(payload)
+}
+
+// deserialize returns a new extended key instance from a serialized extended
+// key payload without a checksum.  The length of the payload must already have
+// been checked.
+func deserialize(payload []byte) (*ExtendedKey, error) {
 	// Deserialize each of the payload fields.
 	version := payload[:4]
 	depth := uint16(payload[4:5][0])
@@ -511,28 +688,58 @@ func NewKeyFromString(key string) (*ExtendedKey, error) {
 	chainCode := payload[13:45]
 	keyData := payload[45:78]
 
+	// The signature suite is secp256k1 unless it is explicitly specified.
+	// Secp256k1 keys are never serialized with an explicit suite so each
+	// key has exactly one serialization.
+	suite := chainec.ECTypeSecp256k1
+	if len(payload) == serializedSuiteKeyLen {
+		suite = int(payload[78])
+		if suite == chainec.ECTypeSecp256k1 {
+			return nil, ErrUnsupportedSuite
+		}
+	}
+	params, err := lookupSuite(suite)
+	if err != nil {
+		return nil, err
+	}
+
 	// The key data is a private key if it starts with 0x00.  Serialized
-	// compressed pubkeys either start with 0x02 or 0x03.
+	// compressed pubkeys either start with 0x02 or 0x03, while Ed25519
+	// pubkeys start with edwardsPubKeyPrefix.
 	isPrivate := keyData[0] == 0x00
 	if isPrivate {
 		// Ensure the private key is valid.  It must be within the range
-		// of the order of the secp256k1 curve and not be 0.
+		// of the order of the curve and not be 0.
 		keyData = keyData[1:]
 		keyNum := new(big.Int).SetBytes(keyData)
-		if keyNum.Cmp(chainec.Secp256k1.GetN()) >= 0 || keyNum.Sign() == 0 {
+		if keyNum.Cmp(params.dsa.GetN()) >= 0 || keyNum.Sign() == 0 {
 			return nil, ErrUnusableSeed
 		}
 	} else {
+		if suite == chainec.ECTypeEdwards {
+			if keyData[0] != edwardsPubKeyPrefix {
+				return nil, ErrInvalidKeyLen
+			}
+			keyData = keyData[1:]
+		}
+
 		// Ensure the public key parses correctly and is actually on the
-		// secp256k1 curve.
-		_, err := chainec.Secp256k1.ParsePubKey(keyData)
+		// curve.
+		_, err := params.dsa.ParsePubKey(keyData)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return newExtendedKey(version, keyData, chainCode, parentFP, depth,
-		childNum, isPrivate), nil
+	key := newExtendedKey(version, keyData, chainCode, parentFP, depth,
+		childNum, isPrivate, suite)
+
+	// The serialized form does not record the path, so the origin is only
+	// known when the key is a master node.
+	if depth == 0 {
+		key.origin = &keyOrigin{}
+	}
+	return key, nil
 }
 
 // GenerateSeed returns a cryptographically secure random seed that can be used
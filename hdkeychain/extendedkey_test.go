@@ -12,6 +12,7 @@ package hdkeychain_test
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"reflect"
 	"testing"
@@ -493,6 +494,74 @@ tests:
 	}
 }
 
+// TestLeadingZeroKeyDerivation ensures the hardened children of secp256k1
+// private keys with a leading zero byte are derived the same way they always
+// have been.  Such keys are kept without their leading zero byte, so padding
+// them would change the children derived by existing wallets.
+func TestLeadingZeroKeyDerivation(t *testing.T) {
+	tests := []struct {
+		childNum     uint32
+		wantChild    string
+		wantHardened string
+	}{
+		{
+			childNum:     382,
+			wantChild:    "aprv2n5Zwpe1k9Gtvdhnx1HxfxwoUMZHPH36grCYK26TvMDVUnsS6Ci3kpWHtTUWdD61rPvz7WNWr1ve9WppYiwmEVjNbcdfCE8t882WUKUHLwd",
+			wantHardened: "aprv2ojGYjx4fHDSktv1QSbT4Ph34fYVhcQMgXaYVqbCVTPkxcTmU72aHvDAuPaqZi2jHVgcGt5rwffsC6PBFXVN52UFsiyXVwfqTFwHzhuCtqf",
+		},
+		{
+			childNum:     421,
+			wantChild:    "aprv2n5Zwpe1k9GvdnG8TPNLeUqsCnDLYAHZKHJrSPSm3VRF9oUM6Pi8Y3hbvPJiHZwL2mzqunrXXoemMKxaGVVwDkrRUQPj1pxkTv3Uc1oHdEH",
+			wantHardened: "aprv2nk2jUgsxH6U6THHp3ksrAZyQCfqyBGwefmKacPHEbZMLHouBX52Zg8k3dVxpFWr72TnMHKTTafbgQqfpB87K76ESbcfaHn7xjUQJZaS4G1",
+		},
+		{
+			childNum:     495,
+			wantChild:    "aprv2n5Zwpe1k9GysfByik34DetBDmoXxpDkMpWfQhbEJUJjRMQqHnDc66AC86EoZbFKZNj5CpbG6S8t1WoXkN1UigX6aVZz3s7Fc7tzZrV3ZDk",
+			wantHardened: "aprv2nZmPetYAmFrnbCSMyx4NkgGFM1F4qGjUhTc7LxCDWWAEkfH1UuTWsTYEtRmM8gsRUt4iX7mZA3CUQug9nNua1N9s5iPcjmAKjtLzRvMeFk",
+		},
+	}
+
+	master, err := hdkeychain.NewMaster(make([]byte, 32),
+		&chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewMaster: unexpected error: %v", err)
+	}
+	for _, test := range tests {
+		child, err := master.Child(test.childNum)
+		if err != nil {
+			t.Errorf("Child(%d): unexpected error: %v", test.childNum,
+				err)
+			continue
+		}
+		hardened, err := child.Child(hdkeychain.HardenedKeyStart)
+		if err != nil {
+			t.Errorf("Child(%d/0'): unexpected error: %v",
+				test.childNum, err)
+			continue
+		}
+
+		for _, key := range []struct {
+			name string
+			key  *hdkeychain.ExtendedKey
+			want string
+		}{
+			{"child", child, test.wantChild},
+			{"hardened child", hardened, test.wantHardened},
+		} {
+			got, err := key.key.String()
+			if err != nil {
+				t.Errorf("%d %s: unexpected error: %v",
+					test.childNum, key.name, err)
+				continue
+			}
+			if got != key.want {
+				t.Errorf("%d %s: mismatched key -- got %v, want %v",
+					test.childNum, key.name, got, key.want)
+			}
+		}
+	}
+}
+
 // TestGenenerateSeed ensures the GenerateSeed function works as intended.
 func TestGenenerateSeed(t *testing.T) {
 	wantErr := errors.New("seed length must be between 128 and 512 bits")
@@ -980,3 +1049,88 @@ func TestZero(t *testing.T) {
 		}
 	}
 }
+
+// TestMarshalling ensures extended keys round trip through their binary, text,
+// and JSON forms and that the accessors return the expected fields.
+func TestMarshalling(t *testing.T) {
+	// Private key at m/0H/1 from [BIP32] test vector 1.
+	const serialized = "aprv2ow6HZ4jikf4WXuauc4i3B5usZmv2uuCECYLoty9RbWrWcXePU6smqtCYKLk2kz4XKSHye8BHgcxKxoziX8aJgXvuyssehc9cn4CEm7eP2F"
+	key, err := hdkeychain.NewKeyFromString(serialized)
+	if err != nil {
+		t.Fatalf("NewKeyFromString: unexpected error: %v", err)
+	}
+
+	if key.Depth() != 2 {
+		t.Errorf("Depth: mismatched depth -- got %d, want 2", key.Depth())
+	}
+	if key.ChildIndex() != 1 {
+		t.Errorf("ChildIndex: mismatched index -- got %d, want 1",
+			key.ChildIndex())
+	}
+	wantChainCode, _ := hex.DecodeString("2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19")
+	if !bytes.Equal(key.ChainCode(), wantChainCode) {
+		t.Errorf("ChainCode: mismatched chain code -- got %x, want %x",
+			key.ChainCode(), wantChainCode)
+	}
+	net := &chaincfg.MainNetParams
+	if !bytes.Equal(key.Version(), net.HDPrivateKeyID[:]) {
+		t.Errorf("Version: mismatched version -- got %x, want %x",
+			key.Version(), net.HDPrivateKeyID[:])
+	}
+
+	// Modifying the returned chain code must not modify the key.
+	key.ChainCode()[0] ^= 0xff
+	if got, _ := key.String(); got != serialized {
+		t.Errorf("ChainCode: modifying returned chain code modified key")
+	}
+
+	// Binary round trip.
+	data, err := key.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: unexpected error: %v", err)
+	}
+	if len(data) != 78 {
+		t.Errorf("MarshalBinary: mismatched length -- got %d, want 78",
+			len(data))
+	}
+	var fromBinary hdkeychain.ExtendedKey
+	if err := fromBinary.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: unexpected error: %v", err)
+	}
+	if got, _ := fromBinary.String(); got != serialized {
+		t.Errorf("UnmarshalBinary: mismatched key -- got %s, want %s",
+			got, serialized)
+	}
+	err = fromBinary.UnmarshalBinary(data[:77])
+	if err != hdkeychain.ErrInvalidKeyLen {
+		t.Errorf("UnmarshalBinary: mismatched error -- got %v, want %v",
+			err, hdkeychain.ErrInvalidKeyLen)
+	}
+
+	// JSON round trip, which uses the text form.
+	type wallet struct {
+		Key *hdkeychain.ExtendedKey `json:"key"`
+	}
+	encoded, err := json.Marshal(wallet{Key: key})
+	if err != nil {
+		t.Fatalf("json.Marshal: unexpected error: %v", err)
+	}
+	wantJSON := `{"key":"` + serialized + `"}`
+	if string(encoded) != wantJSON {
+		t.Errorf("json.Marshal: mismatched JSON -- got %s, want %s",
+			encoded, wantJSON)
+	}
+	var decoded wallet
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal: unexpected error: %v", err)
+	}
+	if got, _ := decoded.Key.String(); got != serialized {
+		t.Errorf("json.Unmarshal: mismatched key -- got %s, want %s",
+			got, serialized)
+	}
+	err = json.Unmarshal([]byte(`{"key":"aprv1234"}`), &decoded)
+	if err != hdkeychain.ErrInvalidKeyLen {
+		t.Errorf("json.Unmarshal: mismatched error -- got %v, want %v",
+			err, hdkeychain.ErrInvalidKeyLen)
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+/*
+Package mnemonic provides an API for encoding hierarchical deterministic wallet
+seeds as human-readable mnemonic word lists (based on BIP0039).
+
+Encoding and Decoding Seeds
+
+The seeds created by hdkeychain.GenerateSeed are raw bytes which are difficult
+to back up by hand.  The Encode function converts a seed into a list of words,
+and the Decode function converts the words back into the original seed which
+may be passed directly to hdkeychain.NewMaster.  Seeds must be a multiple of 4
+bytes between hdkeychain.MinSeedBytes and hdkeychain.MaxSeedBytes, which
+results in mnemonics of between MinWords and MaxWords words.
+
+Each mnemonic includes a checksum of the seed so that mistyped or transposed
+words are detected.  When a word is not in the word list, Decode returns an
+InvalidWordError which identifies the offending word and its position.
+
+Passphrase Stretching
+
+As an alternative to decoding the seed, the NewSeed function stretches the
+mnemonic along with an optional passphrase into a 64 byte seed using
+PBKDF2-HMAC-SHA512.  This is compatible with other BIP0039 implementations and
+allows a single mnemonic to protect several wallets with different passphrases.
+
+Word Lists
+
+The English word list from BIP0039 is provided as English.  Other word lists
+may be used by creating them with NewWordList and passing them to the encoding
+and decoding functions.
+*/
+package mnemonic
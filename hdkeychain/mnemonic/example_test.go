@@ -0,0 +1,35 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mnemonic_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/abcsuite/abcutil/hdkeychain/mnemonic"
+)
+
+// This example demonstrates how to back up a seed as a mnemonic and recover
+// the seed from it.
+func ExampleEncode() {
+	seed := make([]byte, 16)
+	words, err := mnemonic.Encode(seed, mnemonic.English)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(strings.Join(words, " "))
+
+	recovered, err := mnemonic.Decode(words, mnemonic.English)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("%x\n", recovered)
+
+	// Output:
+	// abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about
+	// 00000000000000000000000000000000
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mnemonic
+
+// References:
+//   [BIP39]: BIP0039 - Mnemonic code for generating deterministic keys
+//   https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/abcsuite/abcutil/hdkeychain"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// WordListLen is the number of words in a word list.  Each word encodes
+	// 11 bits.
+	WordListLen = 2048
+
+	// bitsPerWord is the number of bits encoded by each word.
+	bitsPerWord = 11
+
+	// seedBytesStep is the granularity of the seed lengths which may be
+	// encoded.  Every 32 bits of seed adds one bit of checksum, so seeds
+	// must be a multiple of 4 bytes in order for the encoded bits to be a
+	// whole number of words.
+	seedBytesStep = 4
+
+	// MinWords is the number of words which encode a seed of
+	// hdkeychain.MinSeedBytes.
+	MinWords = hdkeychain.MinSeedBytes * 3 / seedBytesStep
+
+	// MaxWords is the number of words which encode a seed of
+	// hdkeychain.MaxSeedBytes.
+	MaxWords = hdkeychain.MaxSeedBytes * 3 / seedBytesStep
+
+	// StretchedSeedLen is the length in bytes of the seeds produced by
+	// NewSeed.
+	StretchedSeedLen = 64
+
+	// stretchIterations is the number of PBKDF2 iterations used when
+	// stretching a mnemonic and passphrase into a seed.
+	stretchIterations = 2048
+
+	// stretchSaltPrefix is prepended to the passphrase to form the PBKDF2
+	// salt.
+	stretchSaltPrefix = "mnemonic"
+)
+
+var (
+	// ErrInvalidSeedLen describes an error in which the provided seed is
+	// not a multiple of 4 bytes in the range allowed by hdkeychain.
+	ErrInvalidSeedLen = fmt.Errorf("seed length must be a multiple of %d "+
+		"bytes between %d and %d bytes", seedBytesStep,
+		hdkeychain.MinSeedBytes, hdkeychain.MaxSeedBytes)
+
+	// ErrInvalidWordCount describes an error in which the number of words
+	// in a mnemonic does not correspond to a supported seed length.
+	ErrInvalidWordCount = fmt.Errorf("mnemonic must be a multiple of 3 "+
+		"words between %d and %d words", MinWords, MaxWords)
+
+	// ErrChecksumMismatch describes an error in which the checksum encoded
+	// in a mnemonic does not match the seed it encodes.  This typically
+	// means words were transposed or mistyped as other valid words.
+	ErrChecksumMismatch = errors.New("mnemonic checksum mismatch")
+
+	// ErrInvalidWordList describes an error in which a word list does not
+	// contain exactly WordListLen unique non-empty words.
+	ErrInvalidWordList = fmt.Errorf("word list must contain exactly %d "+
+		"unique non-empty words", WordListLen)
+)
+
+// InvalidWordError describes an error in which a mnemonic contains a word
+// which is not in the word list it is being decoded with.
+type InvalidWordError struct {
+	// Index is the zero-based position of the invalid word.
+	Index int
+
+	// Word is the invalid word.
+	Word string
+}
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e *InvalidWordError) Error() string {
+	return fmt.Sprintf("invalid mnemonic word %q at position %d", e.Word,
+		e.Index+1)
+}
+
+// WordList is a list of WordListLen words used to encode and decode
+// mnemonics.  Word lists for other languages may be created with NewWordList.
+type WordList struct {
+	words   [WordListLen]string
+	indices map[string]uint16
+}
+
+// English is the English word list defined by [BIP39].
+var English = mustWordList(englishWords[:])
+
+// NewWordList returns a new word list from the passed words, which must be
+// WordListLen unique non-empty words.  The position of each word determines
+// the 11 bits it encodes, so the order must match the order used by other
+// implementations of the list.
+func NewWordList(words []string) (*WordList, error) {
+	if len(words) != WordListLen {
+		return nil, ErrInvalidWordList
+	}
+
+	wl := &WordList{indices: make(map[string]uint16, WordListLen)}
+	for i, word := range words {
+		if word == "" {
+			return nil, ErrInvalidWordList
+		}
+		if _, ok := wl.indices[word]; ok {
+			return nil, ErrInvalidWordList
+		}
+		wl.words[i] = word
+		wl.indices[word] = uint16(i)
+	}
+
+	return wl, nil
+}
+
+// mustWordList returns a new word list from the passed words and panics if
+// they are not a valid word list.  It is only used to create the built-in word
+// lists.
+func mustWordList(words []string) *WordList {
+	wl, err := NewWordList(words)
+	if err != nil {
+		panic(err)
+	}
+	return wl
+}
+
+// Word returns the word at the passed index.  It panics if the index is not
+// less than WordListLen.
+func (wl *WordList) Word(index uint16) string {
+	return wl.words[index]
+}
+
+// Index returns the position of the passed word in the word list and whether
+// or not the word is in the list.
+func (wl *WordList) Index(word string) (uint16, bool) {
+	index, ok := wl.indices[word]
+	return index, ok
+}
+
+// Encode returns the mnemonic for the passed seed using the passed word list.
+// The seed must be a multiple of 4 bytes between hdkeychain.MinSeedBytes and
+// hdkeychain.MaxSeedBytes, which includes seeds of the default length returned
+// by hdkeychain.GenerateSeed.
+//
+// The mnemonic encodes the seed followed by the first len(seed)/4 bits of its
+// SHA-256 hash as a checksum, 11 bits per word, as described by [BIP39].
+func Encode(seed []byte, wl *WordList) ([]string, error) {
+	if len(seed) < hdkeychain.MinSeedBytes ||
+		len(seed) > hdkeychain.MaxSeedBytes ||
+		len(seed)%seedBytesStep != 0 {
+
+		return nil, ErrInvalidSeedLen
+	}
+
+	// The checksum is at most 16 bits, so it always fits in the two bytes
+	// following the seed.
+	checksum := sha256.Sum256(seed)
+	data := make([]byte, len(seed)+2)
+	copy(data, seed)
+	copy(data[len(seed):], checksum[:2])
+
+	numWords := len(seed) * 3 / seedBytesStep
+	words := make([]string, numWords)
+	for i := range words {
+		var index uint16
+		for j := 0; j < bitsPerWord; j++ {
+			index = index<<1 | uint16(bitAt(data, i*bitsPerWord+j))
+		}
+		words[i] = wl.words[index]
+	}
+
+	return words, nil
+}
+
+// Decode returns the seed encoded by the passed mnemonic words using the
+// passed word list.  Callers with a mnemonic sentence should split it into
+// words with strings.Fields.
+//
+// An *InvalidWordError identifying the offending word is returned when a word
+// is not in the word list, and ErrChecksumMismatch is returned when all words
+// are valid but the checksum does not match.
+func Decode(words []string, wl *WordList) ([]byte, error) {
+	if len(words) < MinWords || len(words) > MaxWords || len(words)%3 != 0 {
+		return nil, ErrInvalidWordCount
+	}
+
+	seedLen := len(words) * seedBytesStep / 3
+	data := make([]byte, seedLen+2)
+	for i, word := range words {
+		index, ok := wl.indices[word]
+		if !ok {
+			return nil, &InvalidWordError{Index: i, Word: word}
+		}
+		for j := 0; j < bitsPerWord; j++ {
+			bit := byte(index>>uint(bitsPerWord-1-j)) & 1
+			pos := i*bitsPerWord + j
+			data[pos/8] |= bit << uint(7-pos%8)
+		}
+	}
+
+	seed := data[:seedLen]
+	checksum := sha256.Sum256(seed)
+	checksumBits := seedLen / seedBytesStep
+	for i := 0; i < checksumBits; i++ {
+		if bitAt(checksum[:], i) != bitAt(data, seedLen*8+i) {
+			return nil, ErrChecksumMismatch
+		}
+	}
+
+	return seed, nil
+}
+
+// NewSeed validates the passed mnemonic words against the passed word list and
+// stretches them along with the optional passphrase into a StretchedSeedLen
+// byte seed suitable for hdkeychain.NewMaster.  The stretching uses
+// PBKDF2-HMAC-SHA512 with 2048 iterations and a salt of "mnemonic" followed by
+// the passphrase as described by [BIP39].
+//
+// Note that, unlike the seed returned by Decode, the stretched seed depends on
+// the passphrase, so a different passphrase results in an entirely different,
+// yet equally valid, wallet.  The words and passphrase are used exactly as
+// provided, without any Unicode normalization.
+func NewSeed(words []string, passphrase string, wl *WordList) ([]byte, error) {
+	if _, err := Decode(words, wl); err != nil {
+		return nil, err
+	}
+
+	mnemonic := []byte(strings.Join(words, " "))
+	salt := []byte(stretchSaltPrefix + passphrase)
+	return pbkdf2.Key(mnemonic, salt, stretchIterations, StretchedSeedLen,
+		sha512.New), nil
+}
+
+// bitAt returns the bit at the passed position of the passed big-endian byte
+// slice.
+func bitAt(data []byte, pos int) byte {
+	return data[pos/8] >> uint(7-pos%8) & 1
+}
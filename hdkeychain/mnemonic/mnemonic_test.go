@@ -0,0 +1,219 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package mnemonic_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"testing"
+
+	"github.com/abcsuite/abcutil/hdkeychain"
+	"github.com/abcsuite/abcutil/hdkeychain/mnemonic"
+)
+
+// TestEnglishWordList ensures the English word list matches the [BIP39]
+// english.txt file.
+func TestEnglishWordList(t *testing.T) {
+	var buf bytes.Buffer
+	for i := 0; i < mnemonic.WordListLen; i++ {
+		buf.WriteString(mnemonic.English.Word(uint16(i)))
+		buf.WriteByte('\n')
+	}
+	const want = "c1dbd296"
+	if got := fmt.Sprintf("%08x", crc32.ChecksumIEEE(buf.Bytes())); got != want {
+		t.Fatalf("English: mismatched word list checksum -- got %s, "+
+			"want %s", got, want)
+	}
+}
+
+// TestVectors ensures seeds are encoded, decoded, and stretched as described
+// by the [BIP39] test vectors.
+func TestVectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		seed     string
+		mnemonic string
+		stretch  string
+	}{
+		{
+			name:     "128-bit zero",
+			seed:     "00000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			stretch:  "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			name:     "128-bit 0x7f",
+			seed:     "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			stretch:  "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			name:     "192-bit 0x80",
+			seed:     "808080808080808080808080808080808080808080808080",
+			mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always",
+			stretch:  "107d7c02a5aa6f38c58083ff74f04c607c2d2c0ecc55501dadd72d025b751bc27fe913ffb796f841c49b1d33b610cf0e91d3aa239027f5e99fe4ce9e5088cd65",
+		},
+		{
+			name:     "256-bit 0xff",
+			seed:     "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+			stretch:  "dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+		},
+	}
+
+	for i, test := range tests {
+		seed, _ := hex.DecodeString(test.seed)
+		words, err := mnemonic.Encode(seed, mnemonic.English)
+		if err != nil {
+			t.Errorf("Encode #%d (%s): unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if got := strings.Join(words, " "); got != test.mnemonic {
+			t.Errorf("Encode #%d (%s): mismatched mnemonic -- got %q, "+
+				"want %q", i, test.name, got, test.mnemonic)
+			continue
+		}
+
+		decoded, err := mnemonic.Decode(strings.Fields(test.mnemonic),
+			mnemonic.English)
+		if err != nil {
+			t.Errorf("Decode #%d (%s): unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if !bytes.Equal(decoded, seed) {
+			t.Errorf("Decode #%d (%s): mismatched seed -- got %x, "+
+				"want %x", i, test.name, decoded, seed)
+			continue
+		}
+
+		stretched, err := mnemonic.NewSeed(words, "TREZOR", mnemonic.English)
+		if err != nil {
+			t.Errorf("NewSeed #%d (%s): unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if got := hex.EncodeToString(stretched); got != test.stretch {
+			t.Errorf("NewSeed #%d (%s): mismatched seed -- got %s, "+
+				"want %s", i, test.name, got, test.stretch)
+		}
+	}
+}
+
+// TestRoundTrip ensures every supported seed length round trips and that the
+// stretched seed is usable as a master seed.
+func TestRoundTrip(t *testing.T) {
+	for n := hdkeychain.MinSeedBytes; n <= hdkeychain.MaxSeedBytes; n += 4 {
+		seed := make([]byte, n)
+		for i := range seed {
+			seed[i] = byte(i*7 + n)
+		}
+		words, err := mnemonic.Encode(seed, mnemonic.English)
+		if err != nil {
+			t.Errorf("Encode (%d bytes): unexpected error: %v", n, err)
+			continue
+		}
+		if len(words) != n*3/4 {
+			t.Errorf("Encode (%d bytes): mismatched word count -- got "+
+				"%d, want %d", n, len(words), n*3/4)
+			continue
+		}
+		decoded, err := mnemonic.Decode(words, mnemonic.English)
+		if err != nil {
+			t.Errorf("Decode (%d bytes): unexpected error: %v", n, err)
+			continue
+		}
+		if !bytes.Equal(decoded, seed) {
+			t.Errorf("Decode (%d bytes): mismatched seed -- got %x, "+
+				"want %x", n, decoded, seed)
+		}
+	}
+}
+
+// TestErrors ensures invalid seeds, mnemonics, and word lists are rejected
+// with the expected errors.
+func TestErrors(t *testing.T) {
+	for _, n := range []int{hdkeychain.MinSeedBytes - 4,
+		hdkeychain.MinSeedBytes + 1, hdkeychain.MaxSeedBytes + 4} {
+
+		_, err := mnemonic.Encode(make([]byte, n), mnemonic.English)
+		if err != mnemonic.ErrInvalidSeedLen {
+			t.Errorf("Encode (%d bytes): mismatched error -- got %v, "+
+				"want %v", n, err, mnemonic.ErrInvalidSeedLen)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		mnemonic string
+		err      error
+	}{
+		{
+			name:     "too few words",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon about",
+			err:      mnemonic.ErrInvalidWordCount,
+		},
+		{
+			name:     "not a multiple of 3 words",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			err:      mnemonic.ErrInvalidWordCount,
+		},
+		{
+			name:     "bad checksum",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+			err:      mnemonic.ErrChecksumMismatch,
+		},
+		{
+			name:     "invalid word",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandn abandon abandon abandon about",
+			err:      &mnemonic.InvalidWordError{Index: 7, Word: "abandn"},
+		},
+	}
+	for i, test := range tests {
+		_, err := mnemonic.Decode(strings.Fields(test.mnemonic),
+			mnemonic.English)
+		if wordErr, ok := test.err.(*mnemonic.InvalidWordError); ok {
+			gotErr, ok := err.(*mnemonic.InvalidWordError)
+			if !ok || *gotErr != *wordErr {
+				t.Errorf("Decode #%d (%s): mismatched error -- got "+
+					"%v, want %v", i, test.name, err, test.err)
+			}
+			continue
+		}
+		if err != test.err {
+			t.Errorf("Decode #%d (%s): mismatched error -- got %v, "+
+				"want %v", i, test.name, err, test.err)
+		}
+
+		// Stretching must validate the mnemonic too.
+		_, err = mnemonic.NewSeed(strings.Fields(test.mnemonic), "",
+			mnemonic.English)
+		if err != test.err {
+			t.Errorf("NewSeed #%d (%s): mismatched error -- got %v, "+
+				"want %v", i, test.name, err, test.err)
+		}
+	}
+
+	words := make([]string, mnemonic.WordListLen)
+	for i := range words {
+		words[i] = fmt.Sprintf("word%d", i)
+	}
+	if _, err := mnemonic.NewWordList(words); err != nil {
+		t.Errorf("NewWordList: unexpected error: %v", err)
+	}
+	words[1] = words[0]
+	if _, err := mnemonic.NewWordList(words); err != mnemonic.ErrInvalidWordList {
+		t.Errorf("NewWordList: mismatched error -- got %v, want %v", err,
+			mnemonic.ErrInvalidWordList)
+	}
+	if _, err := mnemonic.NewWordList(words[1:]); err != mnemonic.ErrInvalidWordList {
+		t.Errorf("NewWordList: mismatched error -- got %v, want %v", err,
+			mnemonic.ErrInvalidWordList)
+	}
+}
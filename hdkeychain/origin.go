@@ -0,0 +1,192 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/abcsuite/abcutil"
+)
+
+const (
+	// fingerprintLen is the length of a key fingerprint.
+	fingerprintLen = 4
+
+	// descriptorOpen and descriptorClose enclose the origin of an extended
+	// key in its origin descriptor.
+	descriptorOpen  = "["
+	descriptorClose = "]"
+)
+
+var (
+	// ErrUnknownOrigin describes an error in which the caller requested
+	// the origin descriptor of an extended key whose derivation path from
+	// the master node is not known.
+	ErrUnknownOrigin = errors.New("the origin of the extended key is " +
+		"unknown")
+
+	// ErrInvalidDescriptor describes an error in which an origin
+	// descriptor is malformed or its origin is inconsistent with the
+	// extended key it describes.
+	ErrInvalidDescriptor = errors.New("invalid extended key origin " +
+		"descriptor")
+)
+
+// keyOrigin houses the fingerprint of the master node an extended key was
+// derived from along with the full derivation path from the master node.
+type keyOrigin struct {
+	// masterFP is the fingerprint of the master node.  It is nil when the
+	// extended key is itself the master node, in which case the
+	// fingerprint is calculated from the key on demand.
+	masterFP []byte
+
+	// path is the derivation path from the master node.
+	path DerivationPath
+}
+
+// childOrigin returns the origin of the child at the passed index of an
+// extended key with the passed origin and fingerprint, or nil when the origin
+// of the parent is unknown.
+func childOrigin(parent *keyOrigin, parentFP []byte, i uint32) *keyOrigin {
+	if parent == nil {
+		return nil
+	}
+
+	// Copy the fingerprint of a master parent since it is also the parent
+	// fingerprint of the child, which is cleared when the child is zeroed.
+	masterFP := parent.masterFP
+	if masterFP == nil {
+		masterFP = append([]byte(nil), parentFP...)
+	}
+	path := make(DerivationPath, len(parent.path), len(parent.path)+1)
+	copy(path, parent.path)
+	return &keyOrigin{masterFP: masterFP, path: append(path, i)}
+}
+
+// fingerprint returns the fingerprint of the extended key, which is the first
+// 4 bytes of the Hash160 of its public key.  This is the parent fingerprint of
+// its children.
+func (k *ExtendedKey) fingerprint() []byte {
+	return abcutil.Hash160(k.pubKeyBytes())[:fingerprintLen]
+}
+
+// Path returns the full derivation path of the extended key from its master
+// node and whether or not it is known.  The path is known for master nodes and
+// keys derived from them, including neutered keys, as well as keys parsed from
+// an origin descriptor.  It is not known for keys below the master node which
+// were deserialized from their base58-encoded string or binary forms since
+// those forms only record the depth and index of the key.
+func (k *ExtendedKey) Path() (DerivationPath, bool) {
+	if k.origin == nil {
+		return nil, false
+	}
+	path := make(DerivationPath, len(k.origin.path))
+	copy(path, k.origin.path)
+	return path, true
+}
+
+// MasterFingerprint returns the fingerprint of the master node the extended
+// key was derived from and whether or not it is known.  It is known under the
+// same conditions as the Path.
+func (k *ExtendedKey) MasterFingerprint() (uint32, bool) {
+	if k.origin == nil {
+		return 0, false
+	}
+	if k.origin.masterFP == nil {
+		return binary.BigEndian.Uint32(k.fingerprint()), true
+	}
+	return binary.BigEndian.Uint32(k.origin.masterFP), true
+}
+
+// Descriptor returns the origin descriptor of the extended key, which
+// consists of the hex-encoded master fingerprint and derivation path enclosed
+// in brackets followed by the base58-encoded extended key, for example:
+//   [bc495588/44'/20'/0']apub...
+//
+// The descriptor of a master node omits the path.  ErrUnknownOrigin is
+// returned when the origin of the extended key is not known.
+func (k *ExtendedKey) Descriptor() (string, error) {
+	if k.origin == nil {
+		return "", ErrUnknownOrigin
+	}
+	key, err := k.String()
+	if err != nil {
+		return "", err
+	}
+
+	masterFP := k.origin.masterFP
+	if masterFP == nil {
+		masterFP = k.fingerprint()
+	}
+	path := strings.TrimPrefix(k.origin.path.String(), pathMasterSymbol)
+	return descriptorOpen + hex.EncodeToString(masterFP) + path +
+		descriptorClose + key, nil
+}
+
+// NewKeyFromDescriptor returns a new extended key instance from an origin
+// descriptor as returned by Descriptor.  The origin is checked for
+// consistency with the depth, child index, and parent fingerprint recorded in
+// the extended key, and the resulting key reports the origin through its Path
+// and MasterFingerprint functions.
+//
+// A descriptor without an origin, that is, a bare base58-encoded extended
+// key, is also accepted in which case the result is the same as
+// NewKeyFromString.
+func NewKeyFromDescriptor(descriptor string) (*ExtendedKey, error) {
+	if !strings.HasPrefix(descriptor, descriptorOpen) {
+		return NewKeyFromString(descriptor)
+	}
+
+	end := strings.Index(descriptor, descriptorClose)
+	if end < 0 {
+		return nil, ErrInvalidDescriptor
+	}
+	origin := descriptor[len(descriptorOpen):end]
+	key, err := NewKeyFromString(descriptor[end+len(descriptorClose):])
+	if err != nil {
+		return nil, err
+	}
+
+	// The origin consists of the master fingerprint optionally followed
+	// by a path which shares the form of a textual derivation path
+	// without the leading master symbol.
+	fpHex, pathStr := origin, ""
+	if sep := strings.Index(origin, pathSeparator); sep >= 0 {
+		fpHex, pathStr = origin[:sep], origin[sep:]
+	}
+	masterFP, err := hex.DecodeString(fpHex)
+	if err != nil || len(masterFP) != fingerprintLen {
+		return nil, ErrInvalidDescriptor
+	}
+	path, err := ParsePath(pathMasterSymbol + pathStr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the origin is consistent with the key.  The fingerprint of a
+	// master node must be its own, while the fingerprint of a child of the
+	// master node must be its parent fingerprint.
+	if len(path) != int(key.depth) {
+		return nil, ErrInvalidDescriptor
+	}
+	switch {
+	case len(path) == 0:
+		if !bytes.Equal(masterFP, key.fingerprint()) {
+			return nil, ErrInvalidDescriptor
+		}
+		masterFP = nil
+	case path[len(path)-1] != key.childNum:
+		return nil, ErrInvalidDescriptor
+	case len(path) == 1 && !bytes.Equal(masterFP, key.parentFP):
+		return nil, ErrInvalidDescriptor
+	}
+
+	key.origin = &keyOrigin{masterFP: masterFP, path: path}
+	return key, nil
+}
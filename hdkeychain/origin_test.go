@@ -0,0 +1,182 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain_test
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcutil/hdkeychain"
+)
+
+// TestKeyOriginZero ensures zeroing an extended key does not clear the origin
+// of the keys derived from it.
+func TestKeyOriginZero(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	if err != nil {
+		t.Fatalf("DecodeString: unexpected error: %v", err)
+	}
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewMaster: unexpected error: %v", err)
+	}
+	account, err := master.Child(hdkeychain.HardenedKeyStart)
+	if err != nil {
+		t.Fatalf("Child: unexpected error: %v", err)
+	}
+	child, err := account.Child(1)
+	if err != nil {
+		t.Fatalf("Child: unexpected error: %v", err)
+	}
+	wantDescriptor, err := child.Descriptor()
+	if err != nil {
+		t.Fatalf("Descriptor: unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(wantDescriptor, "[bc495588/0'/1]") {
+		t.Fatalf("Descriptor: mismatched origin -- got %s",
+			wantDescriptor)
+	}
+
+	// Zero the parents of the child, which must leave its origin intact.
+	account.Zero()
+	master.Zero()
+	descriptor, err := child.Descriptor()
+	if err != nil {
+		t.Fatalf("Descriptor: unexpected error: %v", err)
+	}
+	if descriptor != wantDescriptor {
+		t.Errorf("Descriptor: mismatched descriptor after zeroing parent "+
+			"-- got %s, want %s", descriptor, wantDescriptor)
+	}
+	if fp, ok := child.MasterFingerprint(); !ok || fp != 0xbc495588 {
+		t.Errorf("MasterFingerprint: mismatched fingerprint after "+
+			"zeroing parent -- got %08x (known %v), want bc495588", fp,
+			ok)
+	}
+	wantPath, _ := hdkeychain.ParsePath("m/0'/1")
+	if path, ok := child.Path(); !ok || !reflect.DeepEqual(path, wantPath) {
+		t.Errorf("Path: mismatched path after zeroing parent -- got %v "+
+			"(known %v), want %v", path, ok, wantPath)
+	}
+}
+
+// TestKeyOrigin ensures the derivation path and master fingerprint are carried
+// through derivation and neutering and round trip through origin descriptors.
+func TestKeyOrigin(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	if err != nil {
+		t.Fatalf("DecodeString: unexpected error: %v", err)
+	}
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewMaster: unexpected error: %v", err)
+	}
+
+	// The master fingerprint of the [BIP32] test vector 1 master node.
+	const wantFP = 0xbc495588
+	if fp, ok := master.MasterFingerprint(); !ok || fp != wantFP {
+		t.Errorf("MasterFingerprint: mismatched fingerprint -- got %08x "+
+			"(known %v), want %08x", fp, ok, wantFP)
+	}
+	if path, ok := master.Path(); !ok || len(path) != 0 {
+		t.Errorf("Path: mismatched master path -- got %v (known %v), "+
+			"want m", path, ok)
+	}
+
+	path, _ := hdkeychain.ParsePath("m/0'/1/2'")
+	key, err := master.DerivePath(path)
+	if err != nil {
+		t.Fatalf("DerivePath: unexpected error: %v", err)
+	}
+	pub, err := key.Neuter()
+	if err != nil {
+		t.Fatalf("Neuter: unexpected error: %v", err)
+	}
+	for _, k := range []*hdkeychain.ExtendedKey{key, pub} {
+		gotPath, ok := k.Path()
+		if !ok || !reflect.DeepEqual(gotPath, path) {
+			t.Errorf("Path: mismatched path -- got %v (known %v), "+
+				"want %v", gotPath, ok, path)
+		}
+		if fp, ok := k.MasterFingerprint(); !ok || fp != wantFP {
+			t.Errorf("MasterFingerprint: mismatched fingerprint -- "+
+				"got %08x (known %v), want %08x", fp, ok, wantFP)
+		}
+	}
+
+	// Round trip the neutered key through its descriptor and ensure
+	// further derivation extends the path.
+	descriptor, err := pub.Descriptor()
+	if err != nil {
+		t.Fatalf("Descriptor: unexpected error: %v", err)
+	}
+	pubStr, _ := pub.String()
+	wantDescriptor := "[bc495588/0'/1/2']" + pubStr
+	if descriptor != wantDescriptor {
+		t.Errorf("Descriptor: mismatched descriptor -- got %s, want %s",
+			descriptor, wantDescriptor)
+	}
+	parsed, err := hdkeychain.NewKeyFromDescriptor(descriptor)
+	if err != nil {
+		t.Fatalf("NewKeyFromDescriptor: unexpected error: %v", err)
+	}
+	child, err := parsed.Child(2)
+	if err != nil {
+		t.Fatalf("Child: unexpected error: %v", err)
+	}
+	wantPath := append(path, 2)
+	if gotPath, ok := child.Path(); !ok || !reflect.DeepEqual(gotPath, wantPath) {
+		t.Errorf("Path: mismatched path -- got %v (known %v), want %v",
+			gotPath, ok, wantPath)
+	}
+
+	// The path of a key deserialized from its string form is unknown
+	// unless it is a master node.
+	fromString, _ := hdkeychain.NewKeyFromString(pubStr)
+	if _, ok := fromString.Path(); ok {
+		t.Errorf("Path: unexpected known path for deserialized key")
+	}
+	if _, err := fromString.Descriptor(); err != hdkeychain.ErrUnknownOrigin {
+		t.Errorf("Descriptor: mismatched error -- got %v, want %v", err,
+			hdkeychain.ErrUnknownOrigin)
+	}
+	masterDescriptor, err := master.Descriptor()
+	if err != nil {
+		t.Fatalf("Descriptor: unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(masterDescriptor, "[bc495588]") {
+		t.Errorf("Descriptor: mismatched master descriptor -- got %s",
+			masterDescriptor)
+	}
+	if _, err := hdkeychain.NewKeyFromDescriptor(masterDescriptor); err != nil {
+		t.Errorf("NewKeyFromDescriptor: unexpected error: %v", err)
+	}
+
+	depthOne, _ := master.Child(hdkeychain.HardenedKeyStart)
+	depthOneStr, _ := depthOne.String()
+	masterStr, _ := master.String()
+	tests := []struct {
+		name       string
+		descriptor string
+	}{
+		{"unterminated origin", "[bc495588/0'/1/2'" + pubStr},
+		{"bad fingerprint", "[bc49558/0'/1/2']" + pubStr},
+		{"path shorter than depth", "[bc495588/0'/1]" + pubStr},
+		{"mismatched child index", "[bc495588/0'/1/2]" + pubStr},
+		{"mismatched parent fingerprint", "[00000000/0']" + depthOneStr},
+		{"mismatched master fingerprint", "[00000000]" + masterStr},
+	}
+	for i, test := range tests {
+		_, err := hdkeychain.NewKeyFromDescriptor(test.descriptor)
+		if err != hdkeychain.ErrInvalidDescriptor {
+			t.Errorf("NewKeyFromDescriptor #%d (%s): mismatched error "+
+				"-- got %v, want %v", i, test.name, err,
+				hdkeychain.ErrInvalidDescriptor)
+		}
+	}
+}
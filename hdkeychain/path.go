@@ -0,0 +1,226 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxDepth is the maximum depth of an extended key in the hierarchy.
+	// The depth is serialized as a single byte, so keys deeper than this
+	// can't be represented.
+	MaxDepth = 255
+
+	// pathMasterSymbol is the symbol which denotes the master node at the
+	// start of a textual derivation path.
+	pathMasterSymbol = "m"
+
+	// pathSeparator is the separator between the indices of a textual
+	// derivation path.
+	pathSeparator = "/"
+
+	// hardenedSymbol is the canonical suffix used to mark a hardened index
+	// in a textual derivation path.
+	hardenedSymbol = "'"
+)
+
+var (
+	// ErrInvalidPath describes an error in which a textual derivation path
+	// is malformed, such as missing the leading master symbol or
+	// containing an empty segment.
+	ErrInvalidPath = errors.New("invalid derivation path")
+
+	// ErrInvalidPathIndex describes an error in which a segment of a
+	// textual derivation path is not a valid child index.  Indices must be
+	// decimal numbers in the range [0, 2^31 - 1] optionally followed by a
+	// hardened marker.
+	ErrInvalidPathIndex = errors.New("invalid derivation path index")
+
+	// ErrDeriveBeyondMaxDepth describes an error in which the caller
+	// attempted to derive a key deeper than MaxDepth.
+	ErrDeriveBeyondMaxDepth = fmt.Errorf("cannot derive a key with a "+
+		"depth greater than %d", MaxDepth)
+)
+
+// PathError describes an error that occurred while parsing or deriving a
+// derivation path.  It identifies the segment of the path which caused the
+// failure along with the underlying error, such as ErrInvalidChild or
+// ErrDeriveHardFromPublic.
+type PathError struct {
+	// Path is the full textual derivation path.
+	Path string
+
+	// Segment is the zero-based position of the failing segment, not
+	// counting the leading master symbol.  It is -1 when the failure is
+	// not specific to a single segment.
+	Segment int
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error satisfies the error interface and prints human-readable errors.
+func (e *PathError) Error() string {
+	if e.Segment < 0 {
+		return fmt.Sprintf("derivation path %q: %v", e.Path, e.Err)
+	}
+	segments := strings.Split(e.Path, pathSeparator)
+	segment := ""
+	if e.Segment+1 < len(segments) {
+		segment = segments[e.Segment+1]
+	}
+	return fmt.Sprintf("derivation path %q: segment %d (%q): %v", e.Path,
+		e.Segment, segment, e.Err)
+}
+
+// Unwrap returns the underlying error so the error can be matched with
+// errors.Is and errors.As.
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// DerivationPath is a sequence of child indices which describes how to
+// derive a descendant extended key.  Hardened indices are represented by
+// adding HardenedKeyStart to the index just as they are for the Child
+// function.
+type DerivationPath []uint32
+
+// ParsePath parses a textual derivation path such as "m/44'/0'/1/5" into a
+// DerivationPath.  The path must start with the master symbol "m" and each of
+// the following segments must be a decimal index in the range [0, 2^31 - 1].
+// Hardened indices are denoted by a trailing "'" or "h".
+//
+// A *PathError identifying the failing segment is returned when the path is
+// malformed.
+func ParsePath(path string) (DerivationPath, error) {
+	segments := strings.Split(path, pathSeparator)
+	if segments[0] != pathMasterSymbol {
+		return nil, &PathError{Path: path, Segment: -1, Err: ErrInvalidPath}
+	}
+
+	segments = segments[1:]
+	if len(segments) > MaxDepth {
+		return nil, &PathError{Path: path, Segment: MaxDepth,
+			Err: ErrDeriveBeyondMaxDepth}
+	}
+
+	derivationPath := make(DerivationPath, 0, len(segments))
+	for i, segment := range segments {
+		index, err := parsePathIndex(segment)
+		if err != nil {
+			return nil, &PathError{Path: path, Segment: i, Err: err}
+		}
+		derivationPath = append(derivationPath, index)
+	}
+
+	return derivationPath, nil
+}
+
+// parsePathIndex parses a single segment of a textual derivation path into a
+// child index, taking any hardened marker into account.
+func parsePathIndex(segment string) (uint32, error) {
+	if segment == "" {
+		return 0, ErrInvalidPath
+	}
+
+	var offset uint32
+	switch segment[len(segment)-1] {
+	case '\'', 'h', 'H':
+		offset = HardenedKeyStart
+		segment = segment[:len(segment)-1]
+	}
+
+	// Reject signs, whitespace, and anything else strconv would otherwise
+	// tolerate so that every path has a single textual representation
+	// modulo the hardened marker.
+	if segment == "" || segment[0] < '0' || segment[0] > '9' {
+		return 0, ErrInvalidPathIndex
+	}
+	index, err := strconv.ParseUint(segment, 10, 32)
+	if err != nil || index >= HardenedKeyStart {
+		return 0, ErrInvalidPathIndex
+	}
+
+	return uint32(index) + offset, nil
+}
+
+// String returns the derivation path in its textual form, for example
+// "m/44'/0'/1/5".  Hardened indices are always denoted by a trailing "'".
+func (p DerivationPath) String() string {
+	segments := make([]string, 0, len(p)+1)
+	segments = append(segments, pathMasterSymbol)
+	for _, index := range p {
+		if index >= HardenedKeyStart {
+			segments = append(segments, strconv.FormatUint(
+				uint64(index-HardenedKeyStart), 10)+hardenedSymbol)
+			continue
+		}
+		segments = append(segments, strconv.FormatUint(uint64(index), 10))
+	}
+	return strings.Join(segments, pathSeparator)
+}
+
+// IsHardened returns whether or not any index in the derivation path is a
+// hardened index, and therefore whether or not deriving the path requires a
+// private extended key.
+func (p DerivationPath) IsHardened() bool {
+	for _, index := range p {
+		if index >= HardenedKeyStart {
+			return true
+		}
+	}
+	return false
+}
+
+// validate ensures the derivation path can be derived from an extended key at
+// the given depth with the given key type without actually deriving anything.
+func (p DerivationPath) validate(depth uint16, isPrivate bool) error {
+	for i, index := range p {
+		if int(depth)+i+1 > MaxDepth {
+			return &PathError{Path: p.String(), Segment: i,
+				Err: ErrDeriveBeyondMaxDepth}
+		}
+		if !isPrivate && index >= HardenedKeyStart {
+			return &PathError{Path: p.String(), Segment: i,
+				Err: ErrDeriveHardFromPublic}
+		}
+	}
+	return nil
+}
+
+// DerivePath returns the descendant extended key reached by successively
+// deriving each index in the passed path, relative to this extended key.  The
+// master symbol at the start of the path always refers to this extended key,
+// so, for example, deriving "m/0'/1" from an account key results in the
+// account key's hardened child 0 followed by its normal child 1.  An empty
+// path returns this extended key unaltered.
+//
+// The path is validated before any derivation takes place, so a path which
+// requires hardened derivation from a public extended key, or which would
+// exceed MaxDepth, is rejected up front.  All errors are returned as a
+// *PathError which identifies the failing segment.  In particular, when a
+// segment derives to an invalid child the error wraps ErrInvalidChild and the
+// caller is expected to choose a different index for that segment.
+func (k *ExtendedKey) DerivePath(path DerivationPath) (*ExtendedKey, error) {
+	if err := path.validate(k.depth, k.isPrivate); err != nil {
+		return nil, err
+	}
+
+	key := k
+	for i, index := range path {
+		child, err := key.Child(index)
+		if err != nil {
+			return nil, &PathError{Path: path.String(), Segment: i,
+				Err: err}
+		}
+		key = child
+	}
+
+	return key, nil
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain_test
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcutil/hdkeychain"
+)
+
+// TestParsePath ensures textual derivation paths are parsed and printed as
+// intended.
+func TestParsePath(t *testing.T) {
+	hkStart := uint32(hdkeychain.HardenedKeyStart)
+
+	tests := []struct {
+		name      string
+		path      string
+		want      hdkeychain.DerivationPath
+		canonical string
+		err       error
+		segment   int
+	}{
+		{
+			name:      "master only",
+			path:      "m",
+			want:      hdkeychain.DerivationPath{},
+			canonical: "m",
+		},
+		{
+			name:      "bip44 with apostrophes",
+			path:      "m/44'/0'/1/5",
+			want:      hdkeychain.DerivationPath{hkStart + 44, hkStart, 1, 5},
+			canonical: "m/44'/0'/1/5",
+		},
+		{
+			name:      "bip44 with h markers",
+			path:      "m/44h/0H/1/5",
+			want:      hdkeychain.DerivationPath{hkStart + 44, hkStart, 1, 5},
+			canonical: "m/44'/0'/1/5",
+		},
+		{
+			name:      "max indices",
+			path:      "m/2147483647'/2147483647",
+			want:      hdkeychain.DerivationPath{hkStart + 2147483647, 2147483647},
+			canonical: "m/2147483647'/2147483647",
+		},
+		{
+			name:    "missing master",
+			path:    "44'/0'",
+			err:     hdkeychain.ErrInvalidPath,
+			segment: -1,
+		},
+		{
+			name:    "empty segment",
+			path:    "m/44'//1",
+			err:     hdkeychain.ErrInvalidPath,
+			segment: 1,
+		},
+		{
+			name:    "trailing separator",
+			path:    "m/0/",
+			err:     hdkeychain.ErrInvalidPath,
+			segment: 1,
+		},
+		{
+			name:    "index out of range",
+			path:    "m/0/2147483648",
+			err:     hdkeychain.ErrInvalidPathIndex,
+			segment: 1,
+		},
+		{
+			name:    "negative index",
+			path:    "m/-1",
+			err:     hdkeychain.ErrInvalidPathIndex,
+			segment: 0,
+		},
+		{
+			name:    "not a number",
+			path:    "m/0'/x'",
+			err:     hdkeychain.ErrInvalidPathIndex,
+			segment: 1,
+		},
+		{
+			name:    "bare hardened marker",
+			path:    "m/'",
+			err:     hdkeychain.ErrInvalidPathIndex,
+			segment: 0,
+		},
+		{
+			name:    "too deep",
+			path:    "m" + strings.Repeat("/0", hdkeychain.MaxDepth+1),
+			err:     hdkeychain.ErrDeriveBeyondMaxDepth,
+			segment: hdkeychain.MaxDepth,
+		},
+	}
+
+	for i, test := range tests {
+		path, err := hdkeychain.ParsePath(test.path)
+		if test.err != nil {
+			pathErr, ok := err.(*hdkeychain.PathError)
+			if !ok {
+				t.Errorf("ParsePath #%d (%s): unexpected error type "+
+					"-- got %T, want *PathError", i, test.name, err)
+				continue
+			}
+			if pathErr.Err != test.err || pathErr.Segment != test.segment {
+				t.Errorf("ParsePath #%d (%s): mismatched error -- "+
+					"got %v (segment %d), want %v (segment %d)", i,
+					test.name, pathErr.Err, pathErr.Segment,
+					test.err, test.segment)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePath #%d (%s): unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(path, test.want) {
+			t.Errorf("ParsePath #%d (%s): mismatched path -- got %v, "+
+				"want %v", i, test.name, []uint32(path),
+				[]uint32(test.want))
+			continue
+		}
+		if path.String() != test.canonical {
+			t.Errorf("String #%d (%s): mismatched path -- got %s, "+
+				"want %s", i, test.name, path, test.canonical)
+		}
+	}
+}
+
+// TestDerivePath ensures deriving a path produces the same keys as the
+// equivalent sequence of calls to Child and that errors identify the failing
+// segment.
+func TestDerivePath(t *testing.T) {
+	masterSeed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	if err != nil {
+		t.Fatalf("DecodeString: unexpected error: %v", err)
+	}
+	master, err := hdkeychain.NewMaster(masterSeed, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("NewMaster: unexpected error: %v", err)
+	}
+
+	// Derive the deepest key from [BIP32] test vector 1.
+	path, err := hdkeychain.ParsePath("m/0h/1/2h/2/1000000000")
+	if err != nil {
+		t.Fatalf("ParsePath: unexpected error: %v", err)
+	}
+	key, err := master.DerivePath(path)
+	if err != nil {
+		t.Fatalf("DerivePath: unexpected error: %v", err)
+	}
+	wantPriv := "aprv2uojsTCh91GnYMLSbSa44z39fSwL93LPLYDBdD7d6ng8dsujDj47YFLt6fe8XEp9ci85Li3Tv3JvotQQhTX48WoeCsf2KM8tCqUjVqMQARC"
+	if got, _ := key.String(); got != wantPriv {
+		t.Errorf("DerivePath: mismatched serialized private extended "+
+			"key -- got %s, want %s", got, wantPriv)
+	}
+
+	// An empty path refers to the key itself.
+	same, err := master.DerivePath(hdkeychain.DerivationPath{})
+	if err != nil || same != master {
+		t.Errorf("DerivePath: empty path did not return the key itself "+
+			"(err %v)", err)
+	}
+
+	// Deriving a hardened segment from a public key must fail before any
+	// derivation takes place and point at the hardened segment.
+	pub, err := master.Neuter()
+	if err != nil {
+		t.Fatalf("Neuter: unexpected error: %v", err)
+	}
+	path, _ = hdkeychain.ParsePath("m/0/1/2'/3")
+	_, err = pub.DerivePath(path)
+	pathErr, ok := err.(*hdkeychain.PathError)
+	if !ok || pathErr.Err != hdkeychain.ErrDeriveHardFromPublic ||
+		pathErr.Segment != 2 {
+		t.Errorf("DerivePath: mismatched error -- got %v, want %v at "+
+			"segment 2", err, hdkeychain.ErrDeriveHardFromPublic)
+	}
+
+	// Deriving beyond the maximum depth must fail up front.
+	tooDeep := make(hdkeychain.DerivationPath, hdkeychain.MaxDepth)
+	child, err := master.Child(0)
+	if err != nil {
+		t.Fatalf("Child: unexpected error: %v", err)
+	}
+	_, err = child.DerivePath(tooDeep)
+	pathErr, ok = err.(*hdkeychain.PathError)
+	if !ok || pathErr.Err != hdkeychain.ErrDeriveBeyondMaxDepth ||
+		pathErr.Segment != hdkeychain.MaxDepth-1 {
+		t.Errorf("DerivePath: mismatched error -- got %v, want %v at "+
+			"segment %d", err, hdkeychain.ErrDeriveBeyondMaxDepth,
+			hdkeychain.MaxDepth-1)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/abcsuite/abcd/chaincfg/chainec"
+)
+
+const (
+	// edwardsPubKeyPrefix is the byte which prefixes the 32-byte Ed25519
+	// public key in the 33-byte key data field of a serialized extended
+	// public key.  It distinguishes the key data from both private key data,
+	// which is prefixed with 0x00, and compressed secp256k1 public keys,
+	// which are prefixed with 0x02 or 0x03.
+	edwardsPubKeyPrefix = 0x01
+)
+
+// ErrUnsupportedSuite describes an error in which the caller specified, or a
+// serialized extended key contained, a signature suite other than
+// chainec.ECTypeSecp256k1, chainec.ECTypeEdwards, or chainec.ECTypeSecSchnorr.
+var ErrUnsupportedSuite = errors.New("unsupported signature suite")
+
+// suiteParams houses the parameters needed to derive extended keys for a
+// signature suite.
+type suiteParams struct {
+	// dsa is the curve and key implementation used by the suite.
+	dsa chainec.DSA
+
+	// masterKey is the HMAC-SHA512 key used along with a seed to generate
+	// the master node.  Each suite uses a different key so the same seed
+	// never results in the same secret being used with multiple signature
+	// algorithms.
+	masterKey []byte
+
+	// reduceScalars specifies whether or not 256-bit intermediate values
+	// are reduced modulo the group order rather than rejected when they
+	// are out of range.  This is necessary for Ed25519 since its group
+	// order is roughly 2^252, which would otherwise cause the vast
+	// majority of indices to be invalid.
+	reduceScalars bool
+
+	// unpaddedChildKeys specifies whether or not derived child private
+	// keys are kept in their minimal big-endian encoding rather than
+	// padded to 32 bytes.  Since the hardened derivation data copies the
+	// key as is, this changes the hardened children of keys with leading
+	// zero bytes.  It is set for secp256k1 to preserve the keys derived by
+	// existing wallets.
+	unpaddedChildKeys bool
+}
+
+// suites maps each supported signature suite to its parameters.
+var suites = map[int]*suiteParams{
+	chainec.ECTypeSecp256k1: {
+		dsa:               chainec.Secp256k1,
+		masterKey:         masterKey,
+		unpaddedChildKeys: true,
+	},
+	chainec.ECTypeEdwards: {
+		dsa:           chainec.Edwards,
+		masterKey:     []byte("Aero Edwards seed"),
+		reduceScalars: true,
+	},
+	chainec.ECTypeSecSchnorr: {
+		dsa:       chainec.SecSchnorr,
+		masterKey: []byte("Aero SecSchnorr seed"),
+	},
+}
+
+// lookupSuite returns the parameters for the passed signature suite.
+func lookupSuite(suite int) (*suiteParams, error) {
+	params, ok := suites[suite]
+	if !ok {
+		return nil, ErrUnsupportedSuite
+	}
+	return params, nil
+}
+
+// scalar converts the passed 256-bit big-endian value into a private scalar
+// for the suite.  It returns nil when the value is not usable as a private
+// key, that is, when it is zero or, for suites which do not reduce scalars,
+// not less than the group order.
+func (p *suiteParams) scalar(b []byte) *big.Int {
+	n := p.dsa.GetN()
+	num := new(big.Int).SetBytes(b)
+	if p.reduceScalars {
+		num.Mod(num, n)
+	}
+	if num.Cmp(n) >= 0 || num.Sign() == 0 {
+		return nil
+	}
+	return num
+}
+
+// privKey returns the chainec private key for the passed private scalar
+// bytes.
+func (p *suiteParams) privKey(key []byte) chainec.PrivateKey {
+	if p.dsa == chainec.Secp256k1 {
+		privKey, _ := p.dsa.PrivKeyFromBytes(key)
+		return privKey
+	}
+	privKey, _ := p.dsa.PrivKeyFromScalar(key)
+	return privKey
+}
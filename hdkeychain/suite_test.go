@@ -0,0 +1,156 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package hdkeychain_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcd/chaincfg/chainec"
+	"github.com/abcsuite/abcutil/hdkeychain"
+)
+
+// TestSuites ensures extended keys for every signature suite derive
+// consistent private and public children, produce addresses of the matching
+// type, and round trip through serialization with their suite intact.
+func TestSuites(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	if err != nil {
+		t.Fatalf("DecodeString: unexpected error: %v", err)
+	}
+	net := &chaincfg.MainNetParams
+
+	tests := []struct {
+		name  string
+		suite int
+	}{
+		{"secp256k1", chainec.ECTypeSecp256k1},
+		{"edwards", chainec.ECTypeEdwards},
+		{"secschnorr", chainec.ECTypeSecSchnorr},
+	}
+
+	var masterPubKeys [][]byte
+	for i, test := range tests {
+		master, err := hdkeychain.NewMasterForSuite(seed, net, test.suite)
+		if err != nil {
+			t.Errorf("NewMasterForSuite #%d (%s): unexpected error: %v",
+				i, test.name, err)
+			continue
+		}
+		if master.Suite() != test.suite {
+			t.Errorf("Suite #%d (%s): mismatched suite -- got %d, "+
+				"want %d", i, test.name, master.Suite(), test.suite)
+			continue
+		}
+		pub, _ := master.ECPubKey()
+		masterPubKeys = append(masterPubKeys, pub.SerializeCompressed())
+
+		// Deriving a non-hardened child from the private key and then
+		// neutering it must match deriving it from the public key.
+		path, _ := hdkeychain.ParsePath("m/0'/1/2")
+		privChild, err := master.DerivePath(path)
+		if err != nil {
+			t.Errorf("DerivePath #%d (%s): unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		acct, _ := master.Child(hdkeychain.HardenedKeyStart)
+		acctPub, _ := acct.Neuter()
+		pubChild, err := acctPub.DerivePath(path[1:])
+		if err != nil {
+			t.Errorf("DerivePath #%d (%s): unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		neutered, _ := privChild.Neuter()
+		gotPub, _ := pubChild.String()
+		wantPub, _ := neutered.String()
+		if gotPub != wantPub {
+			t.Errorf("Child #%d (%s): mismatched public child -- got "+
+				"%s, want %s", i, test.name, gotPub, wantPub)
+			continue
+		}
+
+		// The private key must correspond to the public key.
+		privKey, err := privChild.ECPrivKey()
+		if err != nil {
+			t.Errorf("ECPrivKey #%d (%s): unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if privKey.GetType() != test.suite {
+			t.Errorf("ECPrivKey #%d (%s): mismatched type -- got %d, "+
+				"want %d", i, test.name, privKey.GetType(), test.suite)
+		}
+		pubKey, err := pubChild.ECPubKey()
+		if err != nil {
+			t.Errorf("ECPubKey #%d (%s): unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		x, y := privKey.Public()
+		if x.Cmp(pubKey.GetX()) != 0 || y.Cmp(pubKey.GetY()) != 0 {
+			t.Errorf("ECPubKey #%d (%s): public key does not match "+
+				"private key", i, test.name)
+		}
+
+		addr, err := pubChild.Address(net)
+		if err != nil {
+			t.Errorf("Address #%d (%s): unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if addr.DSA(net) != test.suite {
+			t.Errorf("Address #%d (%s): mismatched address type -- "+
+				"got %d, want %d", i, test.name, addr.DSA(net),
+				test.suite)
+		}
+
+		// Both private and public keys must round trip with the suite.
+		for _, key := range []*hdkeychain.ExtendedKey{privChild, pubChild} {
+			serialized, _ := key.String()
+			decoded, err := hdkeychain.NewKeyFromString(serialized)
+			if err != nil {
+				t.Errorf("NewKeyFromString #%d (%s): unexpected "+
+					"error: %v", i, test.name, err)
+				continue
+			}
+			if decoded.Suite() != test.suite ||
+				decoded.IsPrivate() != key.IsPrivate() {
+
+				t.Errorf("NewKeyFromString #%d (%s): mismatched "+
+					"suite %d (private %v), want %d (private %v)",
+					i, test.name, decoded.Suite(),
+					decoded.IsPrivate(), test.suite,
+					key.IsPrivate())
+				continue
+			}
+			reserialized, _ := decoded.String()
+			if reserialized != serialized {
+				t.Errorf("String #%d (%s): mismatched key -- got "+
+					"%s, want %s", i, test.name, reserialized,
+					serialized)
+			}
+		}
+	}
+
+	// The same seed must not result in the same key for different suites.
+	for i := 0; i < len(masterPubKeys); i++ {
+		for j := i + 1; j < len(masterPubKeys); j++ {
+			if bytes.Equal(masterPubKeys[i], masterPubKeys[j]) {
+				t.Errorf("NewMasterForSuite: suites %d and %d share "+
+					"the same master key", i, j)
+			}
+		}
+	}
+
+	_, err = hdkeychain.NewMasterForSuite(seed, net, 3)
+	if err != hdkeychain.ErrUnsupportedSuite {
+		t.Errorf("NewMasterForSuite: mismatched error -- got %v, want %v",
+			err, hdkeychain.ErrUnsupportedSuite)
+	}
+}
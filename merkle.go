@@ -0,0 +1,187 @@
+// Copyright (c) 2013-2016 The btcsuite developers
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/abcsuite/abcd/chaincfg/chainhash"
+	"github.com/abcsuite/abcd/wire"
+)
+
+var (
+	// ErrBadMerkleRoot describes an error where the merkle root calculated
+	// from the regular transactions of a block does not match the merkle
+	// root in its header.
+	ErrBadMerkleRoot = errors.New("block merkle root is invalid")
+
+	// ErrBadStakeRoot describes an error where the merkle root calculated
+	// from the stake transactions of a block does not match the stake
+	// root in its header.
+	ErrBadStakeRoot = errors.New("block stake root is invalid")
+
+	// ErrUnknownTxTree describes an error where a transaction tree is
+	// neither the regular nor the stake transaction tree.
+	ErrUnknownTxTree = errors.New("unknown transaction tree")
+)
+
+// hashMerkleBranches returns the hash of the concatenation of the passed left
+// and right child nodes of a merkle tree.
+func hashMerkleBranches(left, right *chainhash.Hash) chainhash.Hash {
+	var concat [chainhash.HashSize * 2]byte
+	copy(concat[:chainhash.HashSize], left[:])
+	copy(concat[chainhash.HashSize:], right[:])
+	return chainhash.HashH(concat[:])
+}
+
+// merkleLevels returns every level of the merkle tree with the passed leaves,
+// starting with the leaves and ending with the root.  A level with an odd
+// number of nodes is paired by hashing its last node with itself.  The tree
+// of no leaves consists of a single zero hash.
+func merkleLevels(leaves []chainhash.Hash) [][]chainhash.Hash {
+	if len(leaves) == 0 {
+		return [][]chainhash.Hash{{{}}}
+	}
+
+	levels := [][]chainhash.Hash{leaves}
+	for level := leaves; len(level) > 1; {
+		next := make([]chainhash.Hash, (len(level)+1)/2)
+		for i := range next {
+			left, right := &level[2*i], &level[2*i]
+			if 2*i+1 < len(level) {
+				right = &level[2*i+1]
+			}
+			next[i] = hashMerkleBranches(left, right)
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	return levels
+}
+
+// merkleLeaves returns the full hashes of the passed transactions, which are
+// the leaves of their merkle tree.
+func merkleLeaves(txns []*Tx) []chainhash.Hash {
+	leaves := make([]chainhash.Hash, len(txns))
+	for i, tx := range txns {
+		leaves[i] = *tx.HashFull()
+	}
+	return leaves
+}
+
+// treeTransactions returns the wrapped transactions of the passed transaction
+// tree of the Block.
+func (b *Block) treeTransactions(tree int8) ([]*Tx, error) {
+	switch tree {
+	case wire.TxTreeRegular:
+		return b.Transactions(), nil
+	case wire.TxTreeStake:
+		return b.STransactions(), nil
+	}
+	return nil, ErrUnknownTxTree
+}
+
+// CalcMerkleRoot returns the merkle root of the regular transactions of the
+// Block.  The leaves of the merkle tree are the full hashes of the
+// transactions, which are memoized by the wrapped transactions.
+func (b *Block) CalcMerkleRoot() chainhash.Hash {
+	levels := merkleLevels(merkleLeaves(b.Transactions()))
+	return levels[len(levels)-1][0]
+}
+
+// CalcStakeMerkleRoot returns the merkle root of the stake transactions of the
+// Block in the same manner as CalcMerkleRoot.
+func (b *Block) CalcStakeMerkleRoot() chainhash.Hash {
+	levels := merkleLevels(merkleLeaves(b.STransactions()))
+	return levels[len(levels)-1][0]
+}
+
+// Verify ensures the merkle roots calculated from the regular and stake
+// transactions of the Block match the merkle root and stake root in its
+// header.  ErrBadMerkleRoot or ErrBadStakeRoot is returned when they do not.
+func (b *Block) Verify() error {
+	// Use the wrapped header rather than MsgBlock so the transactions of
+	// a lazily decoded block are not all decoded into it.
+	header := b.Header().MsgBlockHeader()
+	if root := b.CalcMerkleRoot(); root != header.MerkleRoot {
+		return ErrBadMerkleRoot
+	}
+	if root := b.CalcStakeMerkleRoot(); root != header.StakeRoot {
+		return ErrBadStakeRoot
+	}
+	return nil
+}
+
+// MerkleProof proves the inclusion of a transaction in a merkle tree.  It can
+// be verified against the merkle root or stake root of a block header without
+// access to the rest of the block.
+type MerkleProof struct {
+	// Leaf is the full hash of the transaction.
+	Leaf chainhash.Hash
+
+	// Index is the position of the transaction in its transaction tree.
+	Index int
+
+	// Branches are the sibling hashes of the nodes on the path from the
+	// leaf to the root, ordered from the leaf up.  The sibling of the
+	// last node of a level with an odd number of nodes is the node
+	// itself.
+	Branches []chainhash.Hash
+}
+
+// Root returns the merkle root the proof commits to.
+func (p *MerkleProof) Root() chainhash.Hash {
+	node, index := p.Leaf, p.Index
+	for i := range p.Branches {
+		if index&1 == 0 {
+			node = hashMerkleBranches(&node, &p.Branches[i])
+		} else {
+			node = hashMerkleBranches(&p.Branches[i], &node)
+		}
+		index >>= 1
+	}
+	return node
+}
+
+// Verify returns whether or not the proof shows the transaction with the passed
+// full hash is included in the merkle tree with the passed root.
+func (p *MerkleProof) Verify(txHashFull, root *chainhash.Hash) bool {
+	return p.Leaf == *txHashFull && p.Root() == *root
+}
+
+// MerkleProof returns a proof of the inclusion of the transaction at the
+// passed index of the passed transaction tree, which is either
+// wire.TxTreeRegular or wire.TxTreeStake, in the corresponding merkle root of
+// the Block.
+func (b *Block) MerkleProof(tree int8, txNum int) (*MerkleProof, error) {
+	txns, err := b.treeTransactions(tree)
+	if err != nil {
+		return nil, err
+	}
+	if txNum < 0 || txNum >= len(txns) {
+		str := fmt.Sprintf("transaction index %d is out of range - max %d",
+			txNum, len(txns)-1)
+		return nil, OutOfRangeError(str)
+	}
+
+	levels := merkleLevels(merkleLeaves(txns))
+	proof := &MerkleProof{
+		Leaf:     levels[0][txNum],
+		Index:    txNum,
+		Branches: make([]chainhash.Hash, 0, len(levels)-1),
+	}
+	index := txNum
+	for _, level := range levels[:len(levels)-1] {
+		sibling := index ^ 1
+		if sibling >= len(level) {
+			sibling = index
+		}
+		proof.Branches = append(proof.Branches, level[sibling])
+		index >>= 1
+	}
+	return proof, nil
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg/chainhash"
+	"github.com/abcsuite/abcd/wire"
+	"github.com/abcsuite/abcutil"
+)
+
+// merkleTestBlock returns a copy of Block100000 with the passed number of its
+// transactions in each tree and the merkle roots of its header set to the
+// roots calculated from them.
+func merkleTestBlock(numTx, numSTx int) *abcutil.Block {
+	msgBlock := Block100000
+	msgBlock.Transactions = Block100000.Transactions[:numTx]
+	msgBlock.STransactions = Block100000.Transactions[:numSTx]
+	b := abcutil.NewBlock(&msgBlock)
+	msgBlock.Header.MerkleRoot = b.CalcMerkleRoot()
+	msgBlock.Header.StakeRoot = b.CalcStakeMerkleRoot()
+	return abcutil.NewBlock(&msgBlock)
+}
+
+// TestBlockMerkleRoot ensures the merkle roots of a block are calculated and
+// verified against its header.
+func TestBlockMerkleRoot(t *testing.T) {
+	// The merkle root of two transactions is the hash of their full
+	// hashes concatenated together.
+	msgBlock := Block100000
+	msgBlock.Transactions = Block100000.Transactions[:2]
+	b := abcutil.NewBlock(&msgBlock)
+	txns := Block100000.Transactions
+	left, right := txns[0].TxHashFull(), txns[1].TxHashFull()
+	want := chainhash.HashH(append(left[:], right[:]...))
+	if got := b.CalcMerkleRoot(); got != want {
+		t.Errorf("CalcMerkleRoot: wrong root - got %v, want %v", got, want)
+	}
+
+	// The merkle root of an empty tree is the zero hash.
+	if got := b.CalcStakeMerkleRoot(); got != (chainhash.Hash{}) {
+		t.Errorf("CalcStakeMerkleRoot: wrong root for empty tree - got %v",
+			got)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(header *wire.BlockHeader)
+		err    error
+	}{
+		{"valid", func(*wire.BlockHeader) {}, nil},
+		{"bad merkle root", func(header *wire.BlockHeader) {
+			header.MerkleRoot[0] ^= 0x01
+		}, abcutil.ErrBadMerkleRoot},
+		{"bad stake root", func(header *wire.BlockHeader) {
+			header.StakeRoot[0] ^= 0x01
+		}, abcutil.ErrBadStakeRoot},
+	}
+
+	for _, test := range tests {
+		b := merkleTestBlock(len(txns), 1)
+		test.mutate(&b.MsgBlock().Header)
+		if err := b.Verify(); err != test.err {
+			t.Errorf("%s: Verify: unexpected error - got %v, want %v",
+				test.name, err, test.err)
+		}
+
+		// Lazily decoded blocks are verified against the same header.
+		blockBytes, err := b.MsgBlock().Bytes()
+		if err != nil {
+			t.Fatalf("%s: Bytes: %v", test.name, err)
+		}
+		lazy, err := abcutil.NewBlockFromBytesLazy(blockBytes)
+		if err != nil {
+			t.Fatalf("%s: NewBlockFromBytesLazy: %v", test.name, err)
+		}
+		if err := lazy.Verify(); err != test.err {
+			t.Errorf("%s: Verify (lazy): unexpected error - got %v, "+
+				"want %v", test.name, err, test.err)
+		}
+	}
+}
+
+// TestBlockMerkleProof ensures inclusion proofs are generated for every
+// transaction in both trees of a block and verify against its header alone.
+func TestBlockMerkleProof(t *testing.T) {
+	numTxns := len(Block100000.Transactions)
+	for numTx := 1; numTx <= numTxns; numTx++ {
+		for numSTx := 0; numSTx <= numTxns; numSTx++ {
+			b := merkleTestBlock(numTx, numSTx)
+			header := b.MsgBlock().Header
+			trees := []struct {
+				tree  int8
+				txns  []*abcutil.Tx
+				root  chainhash.Hash
+				other chainhash.Hash
+			}{
+				{wire.TxTreeRegular, b.Transactions(),
+					header.MerkleRoot, header.StakeRoot},
+				{wire.TxTreeStake, b.STransactions(),
+					header.StakeRoot, header.MerkleRoot},
+			}
+
+			for _, tree := range trees {
+				for i, tx := range tree.txns {
+					proof, err := b.MerkleProof(tree.tree, i)
+					if err != nil {
+						t.Errorf("MerkleProof(%d, %d): unexpected "+
+							"error: %v", tree.tree, i, err)
+						continue
+					}
+					if !proof.Verify(tx.HashFull(), &tree.root) {
+						t.Errorf("MerkleProof(%d, %d) of %d: proof "+
+							"does not verify", tree.tree, i,
+							len(tree.txns))
+					}
+
+					// The proof must not verify against the root of
+					// the other tree unless both trees are the same.
+					if tree.root != tree.other &&
+						proof.Verify(tx.HashFull(), &tree.other) {
+
+						t.Errorf("MerkleProof(%d, %d): proof verifies "+
+							"against the wrong root", tree.tree, i)
+					}
+
+					// Tampering with any branch must invalidate
+					// the proof.
+					for j := range proof.Branches {
+						tampered := *proof
+						tampered.Branches = append([]chainhash.Hash(nil),
+							proof.Branches...)
+						tampered.Branches[j][0] ^= 0x01
+						if tampered.Verify(tx.HashFull(), &tree.root) {
+							t.Errorf("MerkleProof(%d, %d): tampered "+
+								"branch %d verifies", tree.tree, i, j)
+						}
+					}
+					// The index is only committed to when the
+					// sibling of the leaf differs from it.
+					if len(proof.Branches) > 0 &&
+						proof.Branches[0] != proof.Leaf {
+
+						tampered := *proof
+						tampered.Index ^= 1
+						if tampered.Verify(tx.HashFull(), &tree.root) {
+							t.Errorf("MerkleProof(%d, %d): tampered "+
+								"index verifies", tree.tree, i)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestBlockMerkleProofErrors ensures errors are returned for proofs of
+// transactions which do not exist.
+func TestBlockMerkleProofErrors(t *testing.T) {
+	b := merkleTestBlock(len(Block100000.Transactions), 0)
+
+	tests := []struct {
+		name  string
+		tree  int8
+		txNum int
+	}{
+		{"negative index", wire.TxTreeRegular, -1},
+		{"index past end", wire.TxTreeRegular, len(Block100000.Transactions)},
+		{"empty stake tree", wire.TxTreeStake, 0},
+	}
+	for _, test := range tests {
+		_, err := b.MerkleProof(test.tree, test.txNum)
+		if _, ok := err.(abcutil.OutOfRangeError); !ok {
+			t.Errorf("%s: wrong error - got %T, want %T", test.name,
+				err, abcutil.OutOfRangeError(""))
+		}
+	}
+
+	_, err := b.MerkleProof(wire.TxTreeUnknown, 0)
+	if err != abcutil.ErrUnknownTxTree {
+		t.Errorf("unknown tree: wrong error - got %v, want %v", err,
+			abcutil.ErrUnknownTxTree)
+	}
+}
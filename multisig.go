@@ -0,0 +1,184 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/abcsuite/abcd/chaincfg"
+)
+
+const (
+	// MaxMultiSigKeys is the maximum number of public keys in a multisig
+	// redeem script.  Both the threshold and number of keys are encoded as
+	// small integer opcodes, which limits them to 16.
+	MaxMultiSigKeys = 16
+
+	// multiSigPubKeyLen is the length of a compressed secp256k1 public key
+	// in a multisig redeem script.
+	multiSigPubKeyLen = 33
+)
+
+var (
+	// ErrInvalidMultiSigThreshold describes an error where the number of
+	// signatures required by a multisig redeem script is zero or greater
+	// than the number of public keys.
+	ErrInvalidMultiSigThreshold = errors.New("multisig threshold must be " +
+		"between 1 and the number of public keys")
+
+	// ErrTooManyMultiSigKeys describes an error where a multisig redeem
+	// script would have more than MaxMultiSigKeys public keys.
+	ErrTooManyMultiSigKeys = errors.New("too many multisig public keys")
+
+	// ErrDuplicateMultiSigKey describes an error where the same public key
+	// was provided more than once for a multisig redeem script.
+	ErrDuplicateMultiSigKey = errors.New("duplicate multisig public key")
+
+	// ErrUnsupportedMultiSigKey describes an error where an address other
+	// than an AddressSecpPubKey or AddressSecSchnorrPubKey was provided for
+	// a multisig redeem script.  OP_CHECKMULTISIG only verifies secp256k1
+	// signatures, so Ed25519 public keys can not be used.
+	ErrUnsupportedMultiSigKey = errors.New("multisig public keys must be " +
+		"secp256k1 public keys")
+
+	// ErrNotMultiSigScript describes an error where a script is not a
+	// standard multisig redeem script.
+	ErrNotMultiSigScript = errors.New("not a multisig redeem script")
+)
+
+// multiSigPubKey returns the compressed secp256k1 public key of the passed
+// address for use in a multisig redeem script.
+func multiSigPubKey(addr Address) ([]byte, error) {
+	var pubKey []byte
+	switch addr := addr.(type) {
+	case *AddressSecpPubKey:
+		pubKey = addr.PubKey().SerializeCompressed()
+	case *AddressSecSchnorrPubKey:
+		// Schnorr public keys are secp256k1 points which are
+		// serialized identically to compressed secp256k1 public keys.
+		pubKey = addr.serialize()
+	default:
+		return nil, ErrUnsupportedMultiSigKey
+	}
+	if len(pubKey) != multiSigPubKeyLen {
+		return nil, ErrUnsupportedMultiSigKey
+	}
+	return pubKey, nil
+}
+
+// MultiSigRedeemScript returns a standard m-of-n multisig redeem script which
+// requires threshold signatures from the passed public keys:
+//   <threshold> <pubkey>... <number of pubkeys> OP_CHECKMULTISIG
+//
+// The public keys must be AddressSecpPubKey or AddressSecSchnorrPubKey
+// addresses.  They are serialized in compressed form and sorted in ascending
+// lexicographic order so that the same set of keys always results in the same
+// script regardless of the order they are provided in.
+//
+// AddressEdwardsPubKey addresses are rejected with ErrUnsupportedMultiSigKey.
+// OP_CHECKMULTISIG only verifies secp256k1 signatures, so a redeem script with
+// an Ed25519 public key could never be spent.
+func MultiSigRedeemScript(threshold int, pubKeys []Address) ([]byte, error) {
+	if len(pubKeys) > MaxMultiSigKeys {
+		return nil, ErrTooManyMultiSigKeys
+	}
+	if threshold < 1 || threshold > len(pubKeys) {
+		return nil, ErrInvalidMultiSigThreshold
+	}
+
+	serialized := make([][]byte, 0, len(pubKeys))
+	for _, addr := range pubKeys {
+		pubKey, err := multiSigPubKey(addr)
+		if err != nil {
+			return nil, err
+		}
+		serialized = append(serialized, pubKey)
+	}
+	sort.Slice(serialized, func(i, j int) bool {
+		return bytes.Compare(serialized[i], serialized[j]) < 0
+	})
+
+	script := make([]byte, 0, 3+len(serialized)*(1+multiSigPubKeyLen))
+	script = append(script, smallIntOpcode(threshold))
+	for i, pubKey := range serialized {
+		if i > 0 && bytes.Equal(pubKey, serialized[i-1]) {
+			return nil, ErrDuplicateMultiSigKey
+		}
+		script = append(script, opData33)
+		script = append(script, pubKey...)
+	}
+	script = append(script, smallIntOpcode(len(serialized)), opCheckMultiSig)
+	return script, nil
+}
+
+// NewAddressMultiSig returns a pay-to-script-hash address for the passed
+// network which pays to a standard m-of-n multisig redeem script along with
+// the redeem script itself.  See MultiSigRedeemScript for details regarding
+// the public keys and threshold.
+func NewAddressMultiSig(threshold int, pubKeys []Address,
+	net *chaincfg.Params) (*AddressScriptHash, []byte, error) {
+
+	script, err := MultiSigRedeemScript(threshold, pubKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	addr, err := NewAddressScriptHash(script, net)
+	if err != nil {
+		return nil, nil, err
+	}
+	return addr, script, nil
+}
+
+// DecodeMultiSigScript returns the number of required signatures and the
+// public keys of the passed standard multisig redeem script, in the order they
+// appear in the script, as addresses for the passed network.
+// ErrNotMultiSigScript is returned when the script is not a standard multisig
+// redeem script with compressed public keys.
+//
+// Every public key is returned as an AddressSecpPubKey.  Schnorr public keys
+// are serialized identically to secp256k1 public keys and the script does not
+// record the signature suite of its keys, so the keys of
+// AddressSecSchnorrPubKey participants are returned as AddressSecpPubKey
+// addresses with the same serialized public key.
+func DecodeMultiSigScript(script []byte,
+	net *chaincfg.Params) (int, []*AddressSecpPubKey, error) {
+
+	// The script must consist of a small integer threshold, at least one
+	// 33-byte data push, a small integer key count, and OP_CHECKMULTISIG.
+	const pushLen = 1 + multiSigPubKeyLen
+	if len(script) < 3+pushLen || (len(script)-3)%pushLen != 0 {
+		return 0, nil, ErrNotMultiSigScript
+	}
+	numPubKeys := (len(script) - 3) / pushLen
+	thresholdOp := script[0]
+	numPubKeysOp := script[len(script)-2]
+	if !isSmallIntOpcode(thresholdOp) || !isSmallIntOpcode(numPubKeysOp) ||
+		smallIntValue(numPubKeysOp) != numPubKeys ||
+		script[len(script)-1] != opCheckMultiSig {
+
+		return 0, nil, ErrNotMultiSigScript
+	}
+	threshold := smallIntValue(thresholdOp)
+	if threshold > numPubKeys {
+		return 0, nil, ErrNotMultiSigScript
+	}
+
+	pubKeys := make([]*AddressSecpPubKey, 0, numPubKeys)
+	for i := 0; i < numPubKeys; i++ {
+		push := script[1+i*pushLen : 1+(i+1)*pushLen]
+		if push[0] != opData33 {
+			return 0, nil, ErrNotMultiSigScript
+		}
+		addr, err := NewAddressSecpPubKey(push[1:], net)
+		if err != nil {
+			return 0, nil, err
+		}
+		pubKeys = append(pubKeys, addr)
+	}
+
+	return threshold, pubKeys, nil
+}
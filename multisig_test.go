@@ -0,0 +1,179 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcutil"
+)
+
+// hexToBytes converts the passed hex string into bytes and will panic if there
+// is an error.  This is only provided for the hard-coded constants so errors
+// in the source code can be detected.
+func hexToBytes(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic("invalid hex in source file: " + s)
+	}
+	return b
+}
+
+// TestMultiSig ensures multisig redeem scripts are constructed
+// deterministically and decode back to their threshold and public keys.
+func TestMultiSig(t *testing.T) {
+	net := &chaincfg.MainNetParams
+	pubKeyHexes := []string{
+		"03b0bd634234abbb1ba1e986e884185c61cf43e001f9137f23c2c409273eb16e65",
+		"0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		"02192d74d0cb94344c9569c2e77901573d8d7903c3ebec3a957724895dca52c6b4",
+	}
+	pubKeys := make([]abcutil.Address, 0, len(pubKeyHexes))
+	for _, pkHex := range pubKeyHexes {
+		addr, err := abcutil.NewAddressSecpPubKey(hexToBytes(pkHex), net)
+		if err != nil {
+			t.Fatalf("NewAddressSecpPubKey: unexpected error: %v", err)
+		}
+		pubKeys = append(pubKeys, addr)
+	}
+
+	// The keys are sorted in the script regardless of their order.
+	wantScript := hexToBytes("52" +
+		"21" + pubKeyHexes[2] +
+		"21" + pubKeyHexes[1] +
+		"21" + pubKeyHexes[0] +
+		"53ae")
+	reversed := []abcutil.Address{pubKeys[2], pubKeys[1], pubKeys[0]}
+	for _, keys := range [][]abcutil.Address{pubKeys, reversed} {
+		addr, script, err := abcutil.NewAddressMultiSig(2, keys, net)
+		if err != nil {
+			t.Fatalf("NewAddressMultiSig: unexpected error: %v", err)
+		}
+		if !bytes.Equal(script, wantScript) {
+			t.Errorf("NewAddressMultiSig: mismatched script -- got %x, "+
+				"want %x", script, wantScript)
+		}
+		wantAddr, _ := abcutil.NewAddressScriptHash(wantScript, net)
+		if addr.EncodeAddress() != wantAddr.EncodeAddress() {
+			t.Errorf("NewAddressMultiSig: mismatched address -- got %s, "+
+				"want %s", addr, wantAddr)
+		}
+	}
+
+	threshold, decoded, err := abcutil.DecodeMultiSigScript(wantScript, net)
+	if err != nil {
+		t.Fatalf("DecodeMultiSigScript: unexpected error: %v", err)
+	}
+	if threshold != 2 {
+		t.Errorf("DecodeMultiSigScript: mismatched threshold -- got %d, "+
+			"want 2", threshold)
+	}
+	if len(decoded) != len(pubKeys) {
+		t.Fatalf("DecodeMultiSigScript: mismatched number of keys -- got "+
+			"%d, want %d", len(decoded), len(pubKeys))
+	}
+	for i, addr := range decoded {
+		want := pubKeyHexes[len(pubKeyHexes)-1-i]
+		if got := hex.EncodeToString(addr.ScriptAddress()); got != want {
+			t.Errorf("DecodeMultiSigScript: mismatched key %d -- got "+
+				"%s, want %s", i, got, want)
+		}
+	}
+
+	// Schnorr public keys share the encoding of secp256k1 public keys.
+	schnorrKey, err := abcutil.NewAddressSecSchnorrPubKey(
+		hexToBytes(pubKeyHexes[0]), net)
+	if err != nil {
+		t.Fatalf("NewAddressSecSchnorrPubKey: unexpected error: %v", err)
+	}
+	script, err := abcutil.MultiSigRedeemScript(1,
+		[]abcutil.Address{schnorrKey})
+	if err != nil {
+		t.Fatalf("MultiSigRedeemScript: unexpected error: %v", err)
+	}
+	want := hexToBytes("5121" + pubKeyHexes[0] + "51ae")
+	if !bytes.Equal(script, want) {
+		t.Errorf("MultiSigRedeemScript: mismatched script -- got %x, "+
+			"want %x", script, want)
+	}
+
+	// The signature suite of a key is not recorded in the script, so a
+	// Schnorr participant decodes as a secp256k1 public key.
+	_, decoded, err = abcutil.DecodeMultiSigScript(script, net)
+	if err != nil {
+		t.Fatalf("DecodeMultiSigScript: unexpected error: %v", err)
+	}
+	if len(decoded) != 1 || !bytes.Equal(decoded[0].ScriptAddress(),
+		schnorrKey.ScriptAddress()) {
+
+		t.Errorf("DecodeMultiSigScript: mismatched Schnorr participant "+
+			"-- got %v, want key %x", decoded,
+			schnorrKey.ScriptAddress())
+	}
+}
+
+// TestMultiSigErrors ensures invalid multisig parameters and scripts are
+// rejected with the expected errors.
+func TestMultiSigErrors(t *testing.T) {
+	net := &chaincfg.MainNetParams
+	secpKey, _ := abcutil.NewAddressSecpPubKey(hexToBytes(
+		"0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"),
+		net)
+	edwardsKey, _ := abcutil.NewAddressEdwardsPubKey(hexToBytes(
+		"cecc1507dc1ddd7295951c290888f095adb9044d1b73d696e6df065d683bd4fc"),
+		net)
+	tooMany := make([]abcutil.Address, abcutil.MaxMultiSigKeys+1)
+	for i := range tooMany {
+		tooMany[i] = secpKey
+	}
+
+	buildTests := []struct {
+		name      string
+		threshold int
+		pubKeys   []abcutil.Address
+		err       error
+	}{
+		{"zero threshold", 0, []abcutil.Address{secpKey}, abcutil.ErrInvalidMultiSigThreshold},
+		{"threshold above keys", 2, []abcutil.Address{secpKey}, abcutil.ErrInvalidMultiSigThreshold},
+		{"no keys", 1, nil, abcutil.ErrInvalidMultiSigThreshold},
+		{"too many keys", 1, tooMany, abcutil.ErrTooManyMultiSigKeys},
+		{"duplicate keys", 1, []abcutil.Address{secpKey, secpKey}, abcutil.ErrDuplicateMultiSigKey},
+		{"edwards key", 1, []abcutil.Address{edwardsKey}, abcutil.ErrUnsupportedMultiSigKey},
+		{"script hash", 1, []abcutil.Address{secpKey.AddressPubKeyHash()}, abcutil.ErrUnsupportedMultiSigKey},
+	}
+	for i, test := range buildTests {
+		_, err := abcutil.MultiSigRedeemScript(test.threshold, test.pubKeys)
+		if err != test.err {
+			t.Errorf("MultiSigRedeemScript #%d (%s): mismatched error -- "+
+				"got %v, want %v", i, test.name, err, test.err)
+		}
+	}
+
+	pk := "210279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	decodeTests := []struct {
+		name   string
+		script string
+	}{
+		{"empty", ""},
+		{"no keys", "5150ae"},
+		{"threshold above keys", "52" + pk + "51ae"},
+		{"mismatched key count", "51" + pk + "52ae"},
+		{"missing checkmultisig", "51" + pk + "51ac"},
+		{"non-small int threshold", "00" + pk + "51ae"},
+		{"wrong push opcode", "5120" + pk[2:] + "51ae"},
+		{"truncated", "51" + pk + "51"},
+	}
+	for i, test := range decodeTests {
+		_, _, err := abcutil.DecodeMultiSigScript(hexToBytes(test.script), net)
+		if err != abcutil.ErrNotMultiSigScript {
+			t.Errorf("DecodeMultiSigScript #%d (%s): mismatched error -- "+
+				"got %v, want %v", i, test.name, err,
+				abcutil.ErrNotMultiSigScript)
+		}
+	}
+}
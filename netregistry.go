@@ -0,0 +1,191 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcd/wire"
+)
+
+var (
+	// ErrDuplicateNetwork describes an error where a network is registered
+	// more than once with a NetRegistry.
+	ErrDuplicateNetwork = errors.New("duplicate network")
+
+	// ErrNetworkCollision describes an error where a network can not be
+	// registered with a NetRegistry because its address prefix or one of
+	// its address identifiers is already used by a registered network, or
+	// two of its own address identifiers are the same, so addresses could
+	// not be attributed to a single network.
+	ErrNetworkCollision = errors.New("network address prefix or " +
+		"identifier collision")
+
+	// ErrUnknownNetwork describes an error where the network of an address
+	// can not be determined because its identifier is not used by any
+	// registered network.
+	ErrUnknownNetwork = errors.New("unknown network for address")
+)
+
+// NetRegistry indexes network parameters by their address prefix, the two-byte
+// identifiers of their address types, and their bech32 human-readable part so
+// the network of an encoded address can be determined.  It is safe for
+// concurrent access.
+type NetRegistry struct {
+	mtx      sync.RWMutex
+	byNet    map[wire.CurrencyNet]*chaincfg.Params
+	byPrefix map[string]*chaincfg.Params
+	byAddrID map[[2]byte]*chaincfg.Params
+	byHRP    map[string]*chaincfg.Params
+}
+
+// NewNetRegistry returns a new empty network registry.
+func NewNetRegistry() *NetRegistry {
+	return &NetRegistry{
+		byNet:    make(map[wire.CurrencyNet]*chaincfg.Params),
+		byPrefix: make(map[string]*chaincfg.Params),
+		byAddrID: make(map[[2]byte]*chaincfg.Params),
+		byHRP:    make(map[string]*chaincfg.Params),
+	}
+}
+
+// addrIDs returns every two-byte address identifier of the passed network.
+func addrIDs(params *chaincfg.Params) [][2]byte {
+	return [][2]byte{
+		params.PubKeyAddrID,
+		params.PubKeyHashAddrID,
+		params.PKHEdwardsAddrID,
+		params.PKHSchnorrAddrID,
+		params.ScriptHashAddrID,
+	}
+}
+
+// Register adds the passed network to the registry.  ErrDuplicateNetwork is
+// returned when a network with the same wire.CurrencyNet is already
+// registered, and ErrNetworkCollision is returned when its address prefix, any
+// of its address identifiers, or its bech32 human-readable part is ambiguous.
+// The registry is not modified when an error is returned.
+func (r *NetRegistry) Register(params *chaincfg.Params) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.byNet[params.Net]; ok {
+		return ErrDuplicateNetwork
+	}
+	if _, ok := r.byPrefix[params.NetworkAddressPrefix]; ok {
+		return ErrNetworkCollision
+	}
+	hrp := Bech32HRP(params)
+	if _, ok := r.byHRP[hrp]; ok {
+		return ErrNetworkCollision
+	}
+	ids := addrIDs(params)
+	seen := make(map[[2]byte]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			return ErrNetworkCollision
+		}
+		if _, ok := r.byAddrID[id]; ok {
+			return ErrNetworkCollision
+		}
+		seen[id] = struct{}{}
+	}
+
+	r.byNet[params.Net] = params
+	if params.NetworkAddressPrefix != "" {
+		r.byPrefix[params.NetworkAddressPrefix] = params
+	}
+	for _, id := range ids {
+		r.byAddrID[id] = params
+	}
+	r.byHRP[hrp] = params
+	return nil
+}
+
+// LookupAddrID returns the registered network which uses the passed two-byte
+// address identifier and whether or not it was found.
+func (r *NetRegistry) LookupAddrID(id [2]byte) (*chaincfg.Params, bool) {
+	r.mtx.RLock()
+	params, ok := r.byAddrID[id]
+	r.mtx.RUnlock()
+	return params, ok
+}
+
+// LookupPrefix returns the registered network which uses the passed address
+// prefix and whether or not it was found.
+func (r *NetRegistry) LookupPrefix(prefix string) (*chaincfg.Params, bool) {
+	r.mtx.RLock()
+	params, ok := r.byPrefix[prefix]
+	r.mtx.RUnlock()
+	return params, ok
+}
+
+// LookupHRP returns the registered network which uses the passed bech32
+// human-readable part and whether or not it was found.
+func (r *NetRegistry) LookupHRP(hrp string) (*chaincfg.Params, bool) {
+	r.mtx.RLock()
+	params, ok := r.byHRP[hrp]
+	r.mtx.RUnlock()
+	return params, ok
+}
+
+// DecodeAddress decodes the base58 or bech32 string encoding of an address
+// and returns the Address if it is a valid encoding for a known address type
+// of a registered network.  The network is determined by the two-byte
+// identifier of a base58 address or the human-readable part of a bech32
+// address, and an AddressDecodeError wrapping ErrUnknownNetwork is returned
+// when no registered network uses it.
+func (r *NetRegistry) DecodeAddress(addr string) (Address, error) {
+	if hrp, ok := bech32AddressHRP(addr); ok {
+		if params, ok := r.LookupHRP(hrp); ok {
+			return decodeAddressBech32(addr, params)
+		}
+	}
+
+	decoded, netID, err := checkDecodeAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	params, ok := r.LookupAddrID(netID)
+	if !ok {
+		return nil, addressDecodeError(DecodeStageNetwork,
+			ErrUnknownNetwork)
+	}
+	return decodeAddressPayload(decoded, netID, params)
+}
+
+// defaultNetRegistry is the registry used by RegisterNet and
+// DecodeNetworkAddress.  It is populated with the standard networks.
+var defaultNetRegistry = NewNetRegistry()
+
+// RegisterNet adds the passed network to the registry used by
+// DecodeNetworkAddress so its addresses can be decoded without specifying the
+// network.  Networks other than the standard main, test, and simulation
+// networks, such as those registered with chaincfg.Register, must be
+// registered with this function as well in order to be detected.  See
+// NetRegistry.Register for the errors which may be returned.
+func RegisterNet(params *chaincfg.Params) error {
+	return defaultNetRegistry.Register(params)
+}
+
+// DecodeNetworkAddress decodes the string encoding of an address and returns
+// the Address if addr is a valid encoding for a known address type. The network
+// type is automatically detected from the two-byte identifier of the address
+// among the standard networks and those added with RegisterNet.
+func DecodeNetworkAddress(addr string) (Address, error) {
+	return defaultNetRegistry.DecodeAddress(addr)
+}
+
+func init() {
+	for _, params := range []*chaincfg.Params{&chaincfg.MainNetParams,
+		&chaincfg.TestNet2Params, &chaincfg.SimNetParams} {
+
+		if err := RegisterNet(params); err != nil {
+			panic("failed to register network: " + err.Error())
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcd/chaincfg/chainec"
+	"github.com/abcsuite/abcutil"
+)
+
+// TestNetRegistry ensures networks are indexed by their address prefix and
+// identifiers, collisions are rejected, and addresses of registered networks
+// are decoded without specifying the network.
+func TestNetRegistry(t *testing.T) {
+	regNet := chaincfg.SimNetParams
+	regNet.Name = "regnet"
+	regNet.Net = 0x7b0e4f2a
+	regNet.NetworkAddressPrefix = "R"
+	regNet.PubKeyAddrID = [2]byte{0x25, 0xe5}
+	regNet.PubKeyHashAddrID = [2]byte{0x0e, 0x00}
+	regNet.PKHEdwardsAddrID = [2]byte{0x0d, 0xe0}
+	regNet.PKHSchnorrAddrID = [2]byte{0x0d, 0xc2}
+	regNet.ScriptHashAddrID = [2]byte{0x0d, 0xdb}
+
+	r := abcutil.NewNetRegistry()
+	for _, params := range []*chaincfg.Params{&chaincfg.MainNetParams,
+		&chaincfg.TestNet2Params, &regNet} {
+
+		if err := r.Register(params); err != nil {
+			t.Fatalf("Register(%s): unexpected error: %v", params.Name,
+				err)
+		}
+	}
+
+	// Ensure the network of every address type is resolved by its
+	// identifier.
+	hash := hexToBytes("e34cce70c86373273efcc54ce7d2a491bb4a0e84")
+	pkh, _ := abcutil.NewAddressPubKeyHash(hash, &regNet,
+		chainec.ECTypeSecSchnorr)
+	sh, _ := abcutil.NewAddressScriptHashFromHash(hash, &regNet)
+	mainPKH, _ := abcutil.NewAddressPubKeyHash(hash,
+		&chaincfg.MainNetParams, chainec.ECTypeSecp256k1)
+	tests := []struct {
+		addr abcutil.Address
+		net  *chaincfg.Params
+	}{
+		{pkh, &regNet},
+		{sh, &regNet},
+		{mainPKH, &chaincfg.MainNetParams},
+	}
+	for i, test := range tests {
+		encoded := test.addr.EncodeAddress()
+		addr, err := r.DecodeAddress(encoded)
+		if err != nil {
+			t.Errorf("DecodeAddress #%d (%s): unexpected error: %v", i,
+				encoded, err)
+			continue
+		}
+		if addr.Net() != test.net || addr.EncodeAddress() != encoded {
+			t.Errorf("DecodeAddress #%d (%s): got %s on %s, want %s on "+
+				"%s", i, encoded, addr.EncodeAddress(), addr.Net().Name,
+				encoded, test.net.Name)
+		}
+	}
+	if params, ok := r.LookupPrefix("R"); !ok || params != &regNet {
+		t.Errorf("LookupPrefix: did not find registered network")
+	}
+
+	// The simulation network is not registered with this registry.
+	simPKH, _ := abcutil.NewAddressPubKeyHash(hash, &chaincfg.SimNetParams,
+		chainec.ECTypeSecp256k1)
+	_, err := r.DecodeAddress(simPKH.EncodeAddress())
+	if !errors.Is(err, abcutil.ErrUnknownNetwork) {
+		t.Errorf("DecodeAddress: mismatched error -- got %v, want %v",
+			err, abcutil.ErrUnknownNetwork)
+	}
+
+	// Ensure duplicate and colliding networks are rejected.
+	prefixCollision := regNet
+	prefixCollision.Name = "collision"
+	prefixCollision.Net = 0x01
+	prefixCollision.PubKeyAddrID = [2]byte{0x01, 0x01}
+	prefixCollision.PubKeyHashAddrID = [2]byte{0x01, 0x02}
+	prefixCollision.PKHEdwardsAddrID = [2]byte{0x01, 0x03}
+	prefixCollision.PKHSchnorrAddrID = [2]byte{0x01, 0x04}
+	prefixCollision.ScriptHashAddrID = [2]byte{0x01, 0x05}
+	idCollision := prefixCollision
+	idCollision.NetworkAddressPrefix = "Q"
+	idCollision.ScriptHashAddrID = chaincfg.MainNetParams.PubKeyHashAddrID
+	selfCollision := prefixCollision
+	selfCollision.NetworkAddressPrefix = "Q"
+	selfCollision.ScriptHashAddrID = selfCollision.PubKeyHashAddrID
+	hrpCollision := prefixCollision
+	hrpCollision.NetworkAddressPrefix = "Q"
+	hrpCollision.Name = "RegNet"
+	errTests := []struct {
+		name   string
+		params *chaincfg.Params
+		err    error
+	}{
+		{"duplicate network", &chaincfg.MainNetParams, abcutil.ErrDuplicateNetwork},
+		{"prefix collision", &prefixCollision, abcutil.ErrNetworkCollision},
+		{"identifier collision", &idCollision, abcutil.ErrNetworkCollision},
+		{"self collision", &selfCollision, abcutil.ErrNetworkCollision},
+		{"bech32 prefix collision", &hrpCollision, abcutil.ErrNetworkCollision},
+	}
+	for i, test := range errTests {
+		if err := r.Register(test.params); err != test.err {
+			t.Errorf("Register #%d (%s): mismatched error -- got %v, "+
+				"want %v", i, test.name, err, test.err)
+		}
+	}
+}
+
+// TestDecodeNetworkAddress ensures addresses of the standard networks are
+// decoded with their network detected.
+func TestDecodeNetworkAddress(t *testing.T) {
+	hash := hexToBytes("e34cce70c86373273efcc54ce7d2a491bb4a0e84")
+	for _, params := range []*chaincfg.Params{&chaincfg.MainNetParams,
+		&chaincfg.TestNet2Params, &chaincfg.SimNetParams} {
+
+		pkh, _ := abcutil.NewAddressPubKeyHash(hash, params,
+			chainec.ECTypeEdwards)
+		addr, err := abcutil.DecodeNetworkAddress(pkh.EncodeAddress())
+		if err != nil {
+			t.Errorf("DecodeNetworkAddress(%s): unexpected error: %v",
+				pkh, err)
+			continue
+		}
+		if addr.Net() != params {
+			t.Errorf("DecodeNetworkAddress(%s): mismatched network -- "+
+				"got %s, want %s", pkh, addr.Net().Name, params.Name)
+		}
+	}
+
+	if err := abcutil.RegisterNet(&chaincfg.MainNetParams); err != abcutil.ErrDuplicateNetwork {
+		t.Errorf("RegisterNet: mismatched error -- got %v, want %v", err,
+			abcutil.ErrDuplicateNetwork)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+// These constants are the values of the script opcodes used by this package.
+// They are defined here rather than imported from txscript since txscript
+// depends on this package.
+const (
+	opData20        = 0x14 // 20
+	opData32        = 0x20 // 32
+	opData33        = 0x21 // 33
+	opData65        = 0x41 // 65
+	op1             = 0x51 // 81
+	op2             = 0x52 // 82
+	op16            = 0x60 // 96
+	opDup           = 0x76 // 118
+	opEqual         = 0x87 // 135
+	opEqualVerify   = 0x88 // 136
+	opHash160       = 0xa9 // 169
+	opCheckSig      = 0xac // 172
+	opCheckMultiSig = 0xae // 174
+	opCheckSigAlt   = 0xbe // 190
+)
+
+// smallIntOpcode returns the opcode which pushes the passed small integer,
+// which must be in the range [1, 16], onto the stack.
+func smallIntOpcode(n int) byte {
+	return byte(op1 - 1 + n)
+}
+
+// isSmallIntOpcode returns whether or not the passed opcode pushes a small
+// integer in the range [1, 16] onto the stack.
+func isSmallIntOpcode(op byte) bool {
+	return op >= op1 && op <= op16
+}
+
+// smallIntValue returns the small integer pushed by the passed opcode, which
+// must be a small integer opcode as determined by isSmallIntOpcode.
+func smallIntValue(op byte) int {
+	return int(op-op1) + 1
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"errors"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcd/chaincfg/chainec"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// DefaultScriptVersion is the script version of the scripts produced by
+// PayToAddrScript and the only script version understood by ExtractAddresses.
+const DefaultScriptVersion = 0
+
+// ErrUnsupportedAddress describes an error where an address can not be paid
+// to with a standard script, either because it is of an unknown type, is nil,
+// or uses an unknown digital signature algorithm.
+var ErrUnsupportedAddress = errors.New("unsupported address type")
+
+// ScriptClass is an enumeration of the standard script templates recognized
+// by ExtractAddresses.  The values are specific to this package and do not
+// match those of the txscript package, so the two types must not be converted
+// between.
+type ScriptClass byte
+
+const (
+	// NonStandardTy is a script which does not match a standard template.
+	NonStandardTy ScriptClass = iota
+
+	// PubKeyTy is a pay-to-pubkey script using a secp256k1 public key.
+	PubKeyTy
+
+	// PubKeyHashTy is a pay-to-pubkey-hash script using a secp256k1 public
+	// key hash.
+	PubKeyHashTy
+
+	// ScriptHashTy is a pay-to-script-hash script.
+	ScriptHashTy
+
+	// MultiSigTy is a bare multisig script.
+	MultiSigTy
+
+	// PubKeyAltTy is a pay-to-pubkey script using an alternative signature
+	// algorithm.
+	PubKeyAltTy
+
+	// PubKeyHashAltTy is a pay-to-pubkey-hash script using an alternative
+	// signature algorithm.
+	PubKeyHashAltTy
+)
+
+// scriptClassToName houses the human-readable strings which describe each
+// script class.
+var scriptClassToName = []string{
+	NonStandardTy:   "nonstandard",
+	PubKeyTy:        "pubkey",
+	PubKeyHashTy:    "pubkeyhash",
+	ScriptHashTy:    "scripthash",
+	MultiSigTy:      "multisig",
+	PubKeyAltTy:     "pubkeyalt",
+	PubKeyHashAltTy: "pubkeyhashalt",
+}
+
+// String implements the Stringer interface by returning the name of the
+// script class as a human-readable string.
+func (c ScriptClass) String() string {
+	if int(c) >= len(scriptClassToName) {
+		return "Invalid"
+	}
+	return scriptClassToName[c]
+}
+
+// sigTypeOpcode returns the opcode which pushes the signature type of the
+// passed alternative digital signature algorithm for OP_CHECKSIGALT.
+func sigTypeOpcode(dsa int) (byte, bool) {
+	switch dsa {
+	case chainec.ECTypeEdwards:
+		return op1, true
+	case chainec.ECTypeSecSchnorr:
+		return op2, true
+	}
+	return 0, false
+}
+
+// payToPubKeyHashScript returns a script which pays to the passed public key
+// hash using the passed digital signature algorithm:
+//   OP_DUP OP_HASH160 <hash> OP_EQUALVERIFY OP_CHECKSIG
+//   OP_DUP OP_HASH160 <hash> OP_EQUALVERIFY <sig type> OP_CHECKSIGALT
+func payToPubKeyHashScript(hash []byte, dsa int) ([]byte, error) {
+	script := make([]byte, 0, 3+len(hash)+3)
+	script = append(script, opDup, opHash160, opData20)
+	script = append(script, hash...)
+	script = append(script, opEqualVerify)
+	if dsa == chainec.ECTypeSecp256k1 {
+		return append(script, opCheckSig), nil
+	}
+	sigType, ok := sigTypeOpcode(dsa)
+	if !ok {
+		return nil, ErrUnsupportedAddress
+	}
+	return append(script, sigType, opCheckSigAlt), nil
+}
+
+// payToPubKeyScript returns a script which pays to the passed serialized
+// public key using the passed digital signature algorithm:
+//   <pubkey> OP_CHECKSIG
+//   <pubkey> <sig type> OP_CHECKSIGALT
+func payToPubKeyScript(pubKey []byte, dsa int) ([]byte, error) {
+	script := make([]byte, 0, 1+len(pubKey)+2)
+	script = append(script, byte(len(pubKey)))
+	script = append(script, pubKey...)
+	if dsa == chainec.ECTypeSecp256k1 {
+		return append(script, opCheckSig), nil
+	}
+	sigType, ok := sigTypeOpcode(dsa)
+	if !ok {
+		return nil, ErrUnsupportedAddress
+	}
+	return append(script, sigType, opCheckSigAlt), nil
+}
+
+// PayToAddrScript returns a standard script which pays to the passed address.
+// Pay-to-pubkey-hash addresses for every digital signature algorithm,
+// pay-to-script-hash addresses, and the secp256k1, Ed25519, and secp256k1
+// Schnorr pay-to-pubkey addresses are supported.  ErrUnsupportedAddress is
+// returned for any other address.
+func PayToAddrScript(addr Address) ([]byte, error) {
+	switch addr := addr.(type) {
+	case *AddressPubKeyHash:
+		if addr == nil || addr.net == nil {
+			return nil, ErrUnsupportedAddress
+		}
+		return payToPubKeyHashScript(addr.hash[:], addr.DSA(addr.net))
+
+	case *AddressScriptHash:
+		if addr == nil {
+			return nil, ErrUnsupportedAddress
+		}
+		script := make([]byte, 0, 2+ripemd160.Size+1)
+		script = append(script, opHash160, opData20)
+		script = append(script, addr.hash[:]...)
+		return append(script, opEqual), nil
+
+	case *AddressSecpPubKey:
+		if addr == nil {
+			return nil, ErrUnsupportedAddress
+		}
+		return payToPubKeyScript(addr.serialize(), chainec.ECTypeSecp256k1)
+
+	case *AddressEdwardsPubKey:
+		if addr == nil {
+			return nil, ErrUnsupportedAddress
+		}
+		return payToPubKeyScript(addr.serialize(), chainec.ECTypeEdwards)
+
+	case *AddressSecSchnorrPubKey:
+		if addr == nil {
+			return nil, ErrUnsupportedAddress
+		}
+		return payToPubKeyScript(addr.serialize(), chainec.ECTypeSecSchnorr)
+	}
+
+	return nil, ErrUnsupportedAddress
+}
+
+// altSigType returns the digital signature algorithm of the signature type
+// pushed by the passed opcode for OP_CHECKSIGALT.
+func altSigType(op byte) (int, bool) {
+	switch op {
+	case op1:
+		return chainec.ECTypeEdwards, true
+	case op2:
+		return chainec.ECTypeSecSchnorr, true
+	}
+	return 0, false
+}
+
+// ExtractAddresses returns the class of the passed public key script along
+// with the addresses it pays to and the number of signatures required to
+// redeem it.  Scripts which do not match a standard template, including all
+// scripts with a version other than DefaultScriptVersion, are reported as
+// NonStandardTy with no addresses.
+//
+// Pay-to-pubkey and multisig scripts with public keys which are not valid for
+// their signature algorithm still report their class and required signatures,
+// but no addresses.
+func ExtractAddresses(version uint16, pkScript []byte,
+	net *chaincfg.Params) (ScriptClass, []Address, int) {
+
+	if version != DefaultScriptVersion {
+		return NonStandardTy, nil, 0
+	}
+
+	switch n := len(pkScript); {
+	// OP_DUP OP_HASH160 <20-byte hash> OP_EQUALVERIFY OP_CHECKSIG
+	case n == 25 && pkScript[0] == opDup && pkScript[1] == opHash160 &&
+		pkScript[2] == opData20 && pkScript[23] == opEqualVerify &&
+		pkScript[24] == opCheckSig:
+
+		addr, err := NewAddressPubKeyHash(pkScript[3:23], net,
+			chainec.ECTypeSecp256k1)
+		if err != nil {
+			return PubKeyHashTy, nil, 1
+		}
+		return PubKeyHashTy, []Address{addr}, 1
+
+	// OP_DUP OP_HASH160 <20-byte hash> OP_EQUALVERIFY <sig type>
+	// OP_CHECKSIGALT
+	case n == 26 && pkScript[0] == opDup && pkScript[1] == opHash160 &&
+		pkScript[2] == opData20 && pkScript[23] == opEqualVerify &&
+		pkScript[25] == opCheckSigAlt:
+
+		dsa, ok := altSigType(pkScript[24])
+		if !ok {
+			break
+		}
+		addr, err := NewAddressPubKeyHash(pkScript[3:23], net, dsa)
+		if err != nil {
+			return PubKeyHashAltTy, nil, 1
+		}
+		return PubKeyHashAltTy, []Address{addr}, 1
+
+	// OP_HASH160 <20-byte hash> OP_EQUAL
+	case n == 23 && pkScript[0] == opHash160 && pkScript[1] == opData20 &&
+		pkScript[22] == opEqual:
+
+		addr, err := NewAddressScriptHashFromHash(pkScript[2:22], net)
+		if err != nil {
+			return ScriptHashTy, nil, 1
+		}
+		return ScriptHashTy, []Address{addr}, 1
+
+	// <33 or 65-byte pubkey> OP_CHECKSIG
+	case (n == 35 && pkScript[0] == opData33 || n == 67 &&
+		pkScript[0] == opData65) && pkScript[n-1] == opCheckSig:
+
+		addr, err := NewAddressSecpPubKey(pkScript[1:n-1], net)
+		if err != nil {
+			return PubKeyTy, nil, 1
+		}
+		return PubKeyTy, []Address{addr}, 1
+
+	// <32-byte pubkey> OP_1 OP_CHECKSIGALT
+	case n == 35 && pkScript[0] == opData32 && pkScript[33] == op1 &&
+		pkScript[34] == opCheckSigAlt:
+
+		addr, err := NewAddressEdwardsPubKey(pkScript[1:33], net)
+		if err != nil {
+			return PubKeyAltTy, nil, 1
+		}
+		return PubKeyAltTy, []Address{addr}, 1
+
+	// <33-byte pubkey> OP_2 OP_CHECKSIGALT
+	case n == 36 && pkScript[0] == opData33 && pkScript[34] == op2 &&
+		pkScript[35] == opCheckSigAlt:
+
+		addr, err := NewAddressSecSchnorrPubKey(pkScript[1:34], net)
+		if err != nil {
+			return PubKeyAltTy, nil, 1
+		}
+		return PubKeyAltTy, []Address{addr}, 1
+	}
+
+	// <threshold> <pubkey>... <number of pubkeys> OP_CHECKMULTISIG
+	threshold, pubKeys, err := DecodeMultiSigScript(pkScript, net)
+	switch {
+	case err == ErrNotMultiSigScript:
+		return NonStandardTy, nil, 0
+	case err != nil:
+		return MultiSigTy, nil, smallIntValue(pkScript[0])
+	}
+	addrs := make([]Address, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		addrs = append(addrs, pubKey)
+	}
+	return MultiSigTy, addrs, threshold
+}
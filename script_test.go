@@ -0,0 +1,168 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcd/chaincfg/chainec"
+	"github.com/abcsuite/abcutil"
+)
+
+// TestPayToAddrScript ensures standard scripts are created for every supported
+// address type and that the addresses are extracted back from them.
+func TestPayToAddrScript(t *testing.T) {
+	net := &chaincfg.MainNetParams
+	const (
+		hash160    = "e34cce70c86373273efcc54ce7d2a491bb4a0e84"
+		secpKey    = "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+		edwardsKey = "cecc1507dc1ddd7295951c290888f095adb9044d1b73d696e6df065d683bd4fc"
+	)
+	mustAddr := func(addr abcutil.Address, err error) abcutil.Address {
+		if err != nil {
+			t.Fatalf("unexpected error creating address: %v", err)
+		}
+		return addr
+	}
+
+	tests := []struct {
+		name     string
+		addr     abcutil.Address
+		script   string
+		class    abcutil.ScriptClass
+		extracts bool
+	}{
+		{
+			name: "secp256k1 pubkey hash",
+			addr: mustAddr(abcutil.NewAddressPubKeyHash(hexToBytes(hash160),
+				net, chainec.ECTypeSecp256k1)),
+			script:   "76a914" + hash160 + "88ac",
+			class:    abcutil.PubKeyHashTy,
+			extracts: true,
+		},
+		{
+			name: "ed25519 pubkey hash",
+			addr: mustAddr(abcutil.NewAddressPubKeyHash(hexToBytes(hash160),
+				net, chainec.ECTypeEdwards)),
+			script:   "76a914" + hash160 + "8851be",
+			class:    abcutil.PubKeyHashAltTy,
+			extracts: true,
+		},
+		{
+			name: "schnorr pubkey hash",
+			addr: mustAddr(abcutil.NewAddressPubKeyHash(hexToBytes(hash160),
+				net, chainec.ECTypeSecSchnorr)),
+			script:   "76a914" + hash160 + "8852be",
+			class:    abcutil.PubKeyHashAltTy,
+			extracts: true,
+		},
+		{
+			name: "script hash",
+			addr: mustAddr(abcutil.NewAddressScriptHashFromHash(
+				hexToBytes(hash160), net)),
+			script:   "a914" + hash160 + "87",
+			class:    abcutil.ScriptHashTy,
+			extracts: true,
+		},
+		{
+			name: "secp256k1 pubkey",
+			addr: mustAddr(abcutil.NewAddressSecpPubKey(hexToBytes(secpKey),
+				net)),
+			script:   "21" + secpKey + "ac",
+			class:    abcutil.PubKeyTy,
+			extracts: true,
+		},
+		{
+			name: "ed25519 pubkey",
+			addr: mustAddr(abcutil.NewAddressEdwardsPubKey(
+				hexToBytes(edwardsKey), net)),
+			script:   "20" + edwardsKey + "51be",
+			class:    abcutil.PubKeyAltTy,
+			extracts: true,
+		},
+		{
+			name: "schnorr pubkey",
+			addr: mustAddr(abcutil.NewAddressSecSchnorrPubKey(
+				hexToBytes(secpKey), net)),
+			script:   "21" + secpKey + "52be",
+			class:    abcutil.PubKeyAltTy,
+			extracts: true,
+		},
+	}
+
+	for i, test := range tests {
+		script, err := abcutil.PayToAddrScript(test.addr)
+		if err != nil {
+			t.Errorf("PayToAddrScript #%d (%s): unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		want := hexToBytes(test.script)
+		if !bytes.Equal(script, want) {
+			t.Errorf("PayToAddrScript #%d (%s): mismatched script -- got "+
+				"%x, want %x", i, test.name, script, want)
+			continue
+		}
+
+		class, addrs, reqSigs := abcutil.ExtractAddresses(
+			abcutil.DefaultScriptVersion, script, net)
+		if class != test.class {
+			t.Errorf("ExtractAddresses #%d (%s): mismatched class -- got "+
+				"%v, want %v", i, test.name, class, test.class)
+		}
+		if reqSigs != 1 {
+			t.Errorf("ExtractAddresses #%d (%s): mismatched required "+
+				"signatures -- got %d, want 1", i, test.name, reqSigs)
+		}
+		if len(addrs) != 1 || addrs[0].String() != test.addr.String() {
+			t.Errorf("ExtractAddresses #%d (%s): mismatched addresses -- "+
+				"got %v, want %v", i, test.name, addrs, test.addr)
+		}
+	}
+
+	if _, err := abcutil.PayToAddrScript(nil); err != abcutil.ErrUnsupportedAddress {
+		t.Errorf("PayToAddrScript: mismatched error -- got %v, want %v",
+			err, abcutil.ErrUnsupportedAddress)
+	}
+}
+
+// TestExtractAddresses ensures multisig and non-standard scripts are
+// classified correctly.
+func TestExtractAddresses(t *testing.T) {
+	net := &chaincfg.MainNetParams
+	pk := "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	tests := []struct {
+		name    string
+		version uint16
+		script  string
+		class   abcutil.ScriptClass
+		addrs   int
+		reqSigs int
+	}{
+		{"multisig", 0, "5121" + pk + "51ae", abcutil.MultiSigTy, 1, 1},
+		{"multisig invalid key", 0, "5121" + "05" + pk[2:] + "51ae",
+			abcutil.MultiSigTy, 0, 1},
+		{"unsupported version", 1, "5121" + pk + "51ae",
+			abcutil.NonStandardTy, 0, 0},
+		{"unknown sig type", 0, "21" + pk + "53be", abcutil.NonStandardTy,
+			0, 0},
+		{"empty", 0, "", abcutil.NonStandardTy, 0, 0},
+		{"op_return", 0, "6a0401020304", abcutil.NonStandardTy, 0, 0},
+	}
+	for i, test := range tests {
+		class, addrs, reqSigs := abcutil.ExtractAddresses(test.version,
+			hexToBytes(test.script), net)
+		if class != test.class || len(addrs) != test.addrs ||
+			reqSigs != test.reqSigs {
+
+			t.Errorf("ExtractAddresses #%d (%s): got class %v, %d "+
+				"addresses, %d required signatures -- want class %v, %d "+
+				"addresses, %d required signatures", i, test.name, class,
+				len(addrs), reqSigs, test.class, test.addrs, test.reqSigs)
+		}
+	}
+}
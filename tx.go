@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/abcsuite/abcd/chaincfg/chainhash"
 	"github.com/abcsuite/abcd/wire"
@@ -27,11 +28,18 @@ const TxIndexUnknown = -1
 // of raw transactions.  It also memoizes the hash for the transaction on its
 // first access so subsequent accesses don't have to repeat the relatively
 // expensive hashing operations.
+//
+// A Tx is safe for concurrent access by multiple goroutines since its hash is
+// calculated when it is created and its full hash is only calculated once.
+// The underlying wire.MsgTx must not be modified once the Tx is shared, and
+// SetIndex and SetTree must not be called concurrently with other accesses.
 type Tx struct {
-	hash    chainhash.Hash // Cached transaction hash
-	msgTx   *wire.MsgTx    // Underlying MsgTx
-	txTree  int8           // Indicates which tx tree the tx is found in
-	txIndex int            // Position within a block or TxIndexUnknown
+	hash         chainhash.Hash // Cached transaction hash
+	hashFull     chainhash.Hash // Cached full transaction hash
+	hashFullOnce sync.Once      // Calculates hashFull on first access
+	msgTx        *wire.MsgTx    // Underlying MsgTx
+	txTree       int8           // Indicates which tx tree the tx is found in
+	txIndex      int            // Position within a block or TxIndexUnknown
 }
 
 // MsgTx returns the underlying wire.MsgTx for the transaction.
@@ -57,6 +65,18 @@ func (t *Tx) Hash() *chainhash.Hash {
 	return &t.hash
 }
 
+// HashFull returns the full hash of the transaction, which commits to both its
+// prefix and witness and is the hash used for the leaves of the merkle trees
+// of a block.  This is equivalent to calling TxHashFull on the underlying
+// wire.MsgTx, however it caches the result so subsequent calls are more
+// efficient.
+func (t *Tx) HashFull() *chainhash.Hash {
+	t.hashFullOnce.Do(func() {
+		t.hashFull = t.msgTx.TxHashFull()
+	})
+	return &t.hashFull
+}
+
 // Index returns the saved index of the transaction within a block.  This value
 // will be TxIndexUnknown if it hasn't already explicitly been set.
 func (t *Tx) Index() int {
@@ -248,3 +268,40 @@ func NewTxFromReader(r io.Reader) (*Tx, error) {
 
 	return &t, nil
 }
+
+// Fee returns the fee paid by the transaction given the values of the previous
+// outputs spent by each of its inputs, in the same order as the inputs.  The
+// values and the total of the outputs must be valid as determined by
+// Amount.IsValid, and ErrNegativeAmount is returned when the outputs exceed the
+// inputs.
+func (t *Tx) Fee(inputValues []Amount) (Amount, error) {
+	msgTx := t.MsgTx()
+	if len(inputValues) != len(msgTx.TxIn) {
+		return 0, ErrInputValueCount
+	}
+	totalIn, err := SumAmounts(inputValues...)
+	if err != nil {
+		return 0, err
+	}
+	outputValues := make([]Amount, 0, len(msgTx.TxOut))
+	for _, txOut := range msgTx.TxOut {
+		outputValues = append(outputValues, Amount(txOut.Value))
+	}
+	totalOut, err := SumAmounts(outputValues...)
+	if err != nil {
+		return 0, err
+	}
+	return totalIn.Sub(totalOut)
+}
+
+// FeeRate returns the fee rate paid by the transaction given the values of the
+// previous outputs spent by each of its inputs, in the same order as the
+// inputs.  The rate is calculated from the serialized size of the transaction
+// and rounded down as described by NewFeeRate.
+func (t *Tx) FeeRate(inputValues []Amount) (FeeRate, error) {
+	fee, err := t.Fee(inputValues)
+	if err != nil {
+		return 0, err
+	}
+	return NewFeeRate(fee, t.MsgTx().SerializeSize())
+}
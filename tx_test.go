@@ -13,6 +13,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/abcsuite/abcd/chaincfg/chainhash"
+	"github.com/abcsuite/abcd/wire"
 	"github.com/abcsuite/abcutil"
 )
 
@@ -122,3 +123,74 @@ func TestTxErrors(t *testing.T) {
 			"got %v, want %v", err, io.EOF)
 	}
 }
+
+// TestTxFee ensures the fee and fee rate of a transaction are calculated from
+// the values of the outputs it spends.
+func TestTxFee(t *testing.T) {
+	msgTx := wire.NewMsgTx()
+	prevOut := wire.NewOutPoint(&chainhash.Hash{}, 0, wire.TxTreeRegular)
+	msgTx.AddTxIn(wire.NewTxIn(prevOut, nil))
+	msgTx.AddTxIn(wire.NewTxIn(prevOut, nil))
+	msgTx.AddTxOut(wire.NewTxOut(3e8, nil))
+	msgTx.AddTxOut(wire.NewTxOut(1e8, nil))
+	tx := abcutil.NewTx(msgTx)
+	size := msgTx.SerializeSize()
+
+	tests := []struct {
+		name        string
+		inputValues []abcutil.Amount
+		fee         abcutil.Amount
+		err         error
+	}{
+		{
+			name:        "fee",
+			inputValues: []abcutil.Amount{2e8, 2e8 + 5000},
+			fee:         5000,
+		},
+		{
+			name:        "no fee",
+			inputValues: []abcutil.Amount{2e8, 2e8},
+			fee:         0,
+		},
+		{
+			name:        "outputs exceed inputs",
+			inputValues: []abcutil.Amount{2e8, 2e8 - 1},
+			err:         abcutil.ErrNegativeAmount,
+		},
+		{
+			name:        "missing input value",
+			inputValues: []abcutil.Amount{4e8},
+			err:         abcutil.ErrInputValueCount,
+		},
+		{
+			name:        "invalid input value",
+			inputValues: []abcutil.Amount{abcutil.MaxAmount + 1, 0},
+			err:         abcutil.ErrAmountExceedsMax,
+		},
+	}
+
+	for _, test := range tests {
+		fee, err := tx.Fee(test.inputValues)
+		if err != test.err {
+			t.Errorf("Fee (%s): mismatched error -- got %v, want %v",
+				test.name, err, test.err)
+			continue
+		}
+		if fee != test.fee {
+			t.Errorf("Fee (%s): mismatched fee -- got %d, want %d",
+				test.name, fee, test.fee)
+		}
+
+		rate, err := tx.FeeRate(test.inputValues)
+		if err != test.err {
+			t.Errorf("FeeRate (%s): mismatched error -- got %v, want %v",
+				test.name, err, test.err)
+			continue
+		}
+		wantRate, _ := abcutil.NewFeeRate(test.fee, size)
+		if rate != wantRate {
+			t.Errorf("FeeRate (%s): mismatched rate -- got %v, want %v",
+				test.name, rate, wantRate)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/abcsuite/abcd/chaincfg"
+)
+
+const (
+	// URIScheme is the scheme of payment URIs.
+	URIScheme = "abc"
+
+	// uriRequiredPrefix is the prefix of the names of parameters which the
+	// recipient requires to be understood by the payer.
+	uriRequiredPrefix = "req-"
+
+	// These constants are the names of the parameters understood by this
+	// package.
+	uriParamAmount  = "amount"
+	uriParamLabel   = "label"
+	uriParamMessage = "message"
+)
+
+var (
+	// ErrInvalidURIScheme describes an error where a payment URI does not
+	// use the URIScheme scheme.
+	ErrInvalidURIScheme = errors.New("payment URI scheme must be " +
+		URIScheme)
+
+	// ErrMalformedURI describes an error where the parameters of a payment
+	// URI are not a valid sequence of percent-encoded name=value pairs or
+	// a parameter is specified more than once.
+	ErrMalformedURI = errors.New("malformed payment URI")
+
+	// ErrUnsupportedURIParam describes an error where a payment URI has a
+	// parameter with the "req-" prefix which is not understood.  Such
+	// parameters must be understood for the payment to be valid, so the
+	// URI is rejected.
+	ErrUnsupportedURIParam = errors.New("unsupported required payment " +
+		"URI parameter")
+
+	// ErrInvalidURIAmount describes an error where the amount of a payment
+	// URI is not a positive decimal amount of coins which can be
+	// represented exactly in atoms and does not exceed MaxAmount.
+	ErrInvalidURIAmount = errors.New("invalid payment URI amount")
+)
+
+// URI describes a payment request of the form:
+//   abc:<address>?amount=<coins>&label=<label>&message=<message>
+//
+// All parameters are optional.  The amount is expressed in coins and the
+// label and message are arbitrary percent-encoded text.
+type URI struct {
+	// Address is the address to pay.
+	Address Address
+
+	// Amount is the requested amount.  It is zero when no amount is
+	// requested.
+	Amount Amount
+
+	// Label is the name of the recipient.
+	Label string
+
+	// Message describes the payment.
+	Message string
+}
+
+// ParseURI parses the passed payment URI and decodes its address for the
+// passed network with DecodeAddress.  The scheme is case insensitive and
+// unknown optional parameters are ignored.
+//
+// The amount is parsed exactly, without any floating point conversion, and
+// ErrInvalidURIAmount is returned when it is not positive, has more precision
+// than an atom, or exceeds MaxAmount.  ErrUnsupportedURIParam is returned for
+// any parameter with the "req-" prefix since no such parameters are currently
+// understood.
+func ParseURI(uri string, net *chaincfg.Params) (*URI, error) {
+	colon := strings.IndexByte(uri, ':')
+	if colon < 0 || !strings.EqualFold(uri[:colon], URIScheme) {
+		return nil, ErrInvalidURIScheme
+	}
+	rest := uri[colon+1:]
+	addrStr, query := rest, ""
+	if q := strings.IndexByte(rest, '?'); q >= 0 {
+		addrStr, query = rest[:q], rest[q+1:]
+	}
+
+	addr, err := DecodeAddress(addrStr, net)
+	if err != nil {
+		return nil, err
+	}
+	result := &URI{Address: addr}
+	if query == "" {
+		return result, nil
+	}
+
+	seen := make(map[string]struct{})
+	for _, param := range strings.Split(query, "&") {
+		eq := strings.IndexByte(param, '=')
+		if eq < 0 {
+			return nil, ErrMalformedURI
+		}
+		name, err := url.PathUnescape(param[:eq])
+		if err != nil {
+			return nil, ErrMalformedURI
+		}
+		value, err := url.PathUnescape(param[eq+1:])
+		if err != nil {
+			return nil, ErrMalformedURI
+		}
+		if _, ok := seen[name]; ok {
+			return nil, ErrMalformedURI
+		}
+		seen[name] = struct{}{}
+
+		switch {
+		case name == uriParamAmount:
+			amount, err := parseDecimalAmount(value, AmountCoin)
+			if err != nil || amount <= 0 || amount > MaxAmount {
+				return nil, ErrInvalidURIAmount
+			}
+			result.Amount = amount
+
+		case name == uriParamLabel:
+			result.Label = value
+
+		case name == uriParamMessage:
+			result.Message = value
+
+		case strings.HasPrefix(name, uriRequiredPrefix):
+			return nil, ErrUnsupportedURIParam
+		}
+	}
+
+	return result, nil
+}
+
+// uriEscape percent-encodes every byte of the passed string other than the
+// unreserved characters of RFC 3986 so it may be used as a parameter value.
+func uriEscape(s string) string {
+	const hex = "0123456789ABCDEF"
+	escaped := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z',
+			'0' <= c && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			escaped = append(escaped, c)
+		default:
+			escaped = append(escaped, '%', hex[c>>4], hex[c&0x0f])
+		}
+	}
+	return string(escaped)
+}
+
+// String returns the payment URI.  The address is encoded with
+// EncodeAddress, and parameters which are empty or zero are omitted.
+func (u *URI) String() string {
+	var params []string
+	if u.Amount != 0 {
+		params = append(params, uriParamAmount+"="+
+			formatDecimalAmount(u.Amount, AmountCoin, AmountTrimZeros,
+				defaultAmountLocale))
+	}
+	if u.Label != "" {
+		params = append(params, uriParamLabel+"="+uriEscape(u.Label))
+	}
+	if u.Message != "" {
+		params = append(params, uriParamMessage+"="+uriEscape(u.Message))
+	}
+
+	uri := URIScheme + ":" + u.Address.EncodeAddress()
+	if len(params) > 0 {
+		uri += "?" + strings.Join(params, "&")
+	}
+	return uri
+}
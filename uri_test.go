@@ -0,0 +1,152 @@
+// Copyright (c) 2017 The Aero Blockchain developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package abcutil_test
+
+import (
+	"testing"
+
+	"github.com/abcsuite/abcd/chaincfg"
+	"github.com/abcsuite/abcd/chaincfg/chainec"
+	"github.com/abcsuite/abcutil"
+)
+
+// TestURI ensures payment URIs are parsed with exact amounts and arbitrary
+// labels and messages round trip.
+func TestURI(t *testing.T) {
+	net := &chaincfg.MainNetParams
+	hash := hexToBytes("e34cce70c86373273efcc54ce7d2a491bb4a0e84")
+	pkh, _ := abcutil.NewAddressPubKeyHash(hash, net, chainec.ECTypeSecp256k1)
+	addr := pkh.EncodeAddress()
+
+	tests := []struct {
+		name    string
+		uri     string
+		amount  abcutil.Amount
+		label   string
+		message string
+		encoded string
+	}{
+		{
+			name:    "address only",
+			uri:     "abc:" + addr,
+			encoded: "abc:" + addr,
+		},
+		{
+			name:    "all parameters",
+			uri:     "abc:" + addr + "?amount=1.5&label=Luke-Jr&message=Donation%20for%20project%20xyz",
+			amount:  150000000,
+			label:   "Luke-Jr",
+			message: "Donation for project xyz",
+			encoded: "abc:" + addr + "?amount=1.5&label=Luke-Jr&message=Donation%20for%20project%20xyz",
+		},
+		{
+			name:    "uppercase scheme and ignored parameter",
+			uri:     "ABC:" + addr + "?somethingyoudontunderstand=50&amount=20.3",
+			amount:  2030000000,
+			encoded: "abc:" + addr + "?amount=20.3",
+		},
+		{
+			name:    "smallest amount",
+			uri:     "abc:" + addr + "?amount=0.00000001",
+			amount:  1,
+			encoded: "abc:" + addr + "?amount=0.00000001",
+		},
+		{
+			name:    "insignificant zeros",
+			uri:     "abc:" + addr + "?amount=0012.3400000000",
+			amount:  1234000000,
+			encoded: "abc:" + addr + "?amount=12.34",
+		},
+		{
+			name:    "max amount",
+			uri:     "abc:" + addr + "?amount=21000000",
+			amount:  abcutil.MaxAmount,
+			encoded: "abc:" + addr + "?amount=21000000",
+		},
+		{
+			name:    "reserved characters and unicode",
+			uri:     "abc:" + addr + "?label=%26%3D%25+%2F%3F%23%E2%82%AC",
+			label:   "&=%+/?#€",
+			encoded: "abc:" + addr + "?label=%26%3D%25%2B%2F%3F%23%E2%82%AC",
+		},
+	}
+
+	for i, test := range tests {
+		uri, err := abcutil.ParseURI(test.uri, net)
+		if err != nil {
+			t.Errorf("ParseURI #%d (%s): unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+		if uri.Address.EncodeAddress() != addr || uri.Amount != test.amount ||
+			uri.Label != test.label || uri.Message != test.message {
+
+			t.Errorf("ParseURI #%d (%s): got %+v, want address %s, "+
+				"amount %d, label %q, message %q", i, test.name, uri,
+				addr, test.amount, test.label, test.message)
+			continue
+		}
+
+		encoded := uri.String()
+		if encoded != test.encoded {
+			t.Errorf("String #%d (%s): mismatched URI -- got %s, want %s",
+				i, test.name, encoded, test.encoded)
+		}
+		reparsed, err := abcutil.ParseURI(encoded, net)
+		if err != nil {
+			t.Errorf("ParseURI #%d (%s): unexpected error reparsing: %v",
+				i, test.name, err)
+			continue
+		}
+		if reparsed.Address.EncodeAddress() != addr ||
+			reparsed.Amount != uri.Amount || reparsed.Label != uri.Label ||
+			reparsed.Message != uri.Message {
+
+			t.Errorf("ParseURI #%d (%s): mismatched round trip -- got "+
+				"%+v, want %+v", i, test.name, reparsed, uri)
+		}
+	}
+}
+
+// TestURIErrors ensures invalid payment URIs are rejected with the expected
+// errors.
+func TestURIErrors(t *testing.T) {
+	net := &chaincfg.MainNetParams
+	hash := hexToBytes("e34cce70c86373273efcc54ce7d2a491bb4a0e84")
+	pkh, _ := abcutil.NewAddressPubKeyHash(hash, net, chainec.ECTypeSecp256k1)
+	addr := pkh.EncodeAddress()
+
+	tests := []struct {
+		name string
+		uri  string
+		err  error
+	}{
+		{"wrong scheme", "bitcoin:" + addr, abcutil.ErrInvalidURIScheme},
+		{"missing scheme", addr, abcutil.ErrInvalidURIScheme},
+		{"required parameter", "abc:" + addr + "?req-somethingyoudontunderstand=50", abcutil.ErrUnsupportedURIParam},
+		{"duplicate parameter", "abc:" + addr + "?label=a&label=b", abcutil.ErrMalformedURI},
+		{"missing value", "abc:" + addr + "?label", abcutil.ErrMalformedURI},
+		{"bad escape", "abc:" + addr + "?label=%zz", abcutil.ErrMalformedURI},
+		{"amount too precise", "abc:" + addr + "?amount=1.000000001", abcutil.ErrInvalidURIAmount},
+		{"amount exponent", "abc:" + addr + "?amount=1e3", abcutil.ErrInvalidURIAmount},
+		{"negative amount", "abc:" + addr + "?amount=-1", abcutil.ErrInvalidURIAmount},
+		{"zero amount", "abc:" + addr + "?amount=0", abcutil.ErrInvalidURIAmount},
+		{"empty amount", "abc:" + addr + "?amount=", abcutil.ErrInvalidURIAmount},
+		{"amount above max", "abc:" + addr + "?amount=21000000.00000001", abcutil.ErrInvalidURIAmount},
+		{"amount overflow", "abc:" + addr + "?amount=999999999999", abcutil.ErrInvalidURIAmount},
+	}
+	for i, test := range tests {
+		_, err := abcutil.ParseURI(test.uri, net)
+		if err != test.err {
+			t.Errorf("ParseURI #%d (%s): mismatched error -- got %v, "+
+				"want %v", i, test.name, err, test.err)
+		}
+	}
+
+	// Addresses for another network are rejected.
+	if _, err := abcutil.ParseURI("abc:"+addr, &chaincfg.TestNet2Params); err == nil {
+		t.Errorf("ParseURI: expected error for address on another network")
+	}
+}